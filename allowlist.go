@@ -0,0 +1,132 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// propertyFields maps registry properties to the response fields that report
+// them. armstrong, even and odd are entries of "properties" instead, and the
+// rest exist only in the registry.
+var propertyFields = map[string][]string{
+	"prime":               {"is_prime"},
+	"perfect":             {"is_perfect", "abundance"}, // Abundance 0 means perfect
+	"practical":           {"is_practical"},
+	"power_of_two":        {"is_power_of_two"},
+	"triangular":          {"is_triangular", "triangular_index"},
+	"square":              {"is_square", "square_index"},
+	"evil":                {"is_evil"},
+	"odious":              {"is_odious"},
+	"carmichael":          {"is_carmichael"},
+	"self":                {"is_self_number"},
+	"sphenic":             {"is_sphenic"},
+	"achilles":            {"is_achilles"},
+	"undulating":          {"is_undulating"},
+	"circular_prime":      {"is_circular_prime"},
+	"primorial":           {"is_primorial"},
+	"duffinian":           {"is_duffinian"},
+	"hoax":                {"is_hoax"},
+	"keith":               {"is_keith"},
+	"disarium":            {"is_disarium"},
+	"binary_palindrome":   {"is_binary_palindrome"},
+	"highly_composite":    {"is_highly_composite"},
+	"pandigital":          {"is_pandigital"},
+	"zeroless_pandigital": {"is_zeroless_pandigital"},
+}
+
+// disabledProperties and disabledFields are what ENABLED_PROPERTIES leaves
+// out; both are empty when it is unset.
+var disabledProperties, disabledFields = applyPropertyAllowlist(cfg.EnabledProperties)
+
+// applyPropertyAllowlist removes every property not in enabled from the
+// registry, so no endpoint accepts it, and returns what was removed. An
+// unknown name is fatal rather than silently disabling the property meant.
+func applyPropertyAllowlist(enabled []string) (map[string]bool, map[string]bool) {
+	properties, fields := map[string]bool{}, map[string]bool{}
+	if len(enabled) == 0 {
+		return properties, fields
+	}
+	allowed := map[string]bool{}
+	for _, name := range enabled {
+		name = canonicalProperty(strings.ToLower(name))
+		if _, ok := propertyRegistry[name]; !ok {
+			log.Fatalf("Unknown property %q in ENABLED_PROPERTIES; valid: %s", name, strings.Join(propertyNames(), ", "))
+		}
+		allowed[name] = true
+	}
+	for _, name := range propertyNames() {
+		if allowed[name] {
+			continue
+		}
+		properties[name] = true
+		for _, field := range propertyFields[name] {
+			fields[field] = true
+		}
+		delete(propertyRegistry, name)
+	}
+	log.Printf("Property allowlist enabled: %d of %d properties", len(allowed), len(allowed)+len(properties))
+	return properties, fields
+}
+
+// anyEnabled reports whether any of the named fields belongs to an enabled
+// property (or to no property at all).
+func anyEnabled(names ...string) bool {
+	return slices.ContainsFunc(names, func(name string) bool { return !disabledFields[name] })
+}
+
+// hidesDisabled reports whether t is a classification whose disabled fields
+// are left out of responses.
+func hidesDisabled(t reflect.Type) bool {
+	return t == reflect.TypeOf(Classification{}) || t == reflect.TypeOf(bigClassification{})
+}
+
+// enabledFields masks a response of type t down to the fields of enabled
+// properties, including classifications nested in it. It is nil when every
+// property is enabled.
+func enabledFields(t reflect.Type) fieldMask {
+	if len(disabledFields) == 0 {
+		return nil
+	}
+	mask := fieldMask{}
+	for name, ft := range jsonFields(t) {
+		for ft.Kind() == reflect.Pointer || ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+		}
+		mask[name] = nil
+		if hidesDisabled(ft) {
+			mask[name] = enabledFields(ft)
+		}
+	}
+	return mask
+}
+
+// renderEnabled renders v without the fields of disabled properties.
+func renderEnabled(c *gin.Context, status int, v interface{}) {
+	renderMasked(c, status, v, enabledFields(reflect.TypeOf(v)))
+}
+
+// capabilities is the body of GET /api/capabilities.
+type capabilities struct {
+	Properties []string          `json:"properties"` // Accepted wherever a property name is
+	Fields     []string          `json:"fields"`     // Classification fields, for ?fields=
+	Sequences  []string          `json:"sequences"`  // Names accepted by /api/sequence
+	Aliases    map[string]string `json:"aliases"`    // Alternate property names and what they resolve to
+	Endpoints  []string          `json:"endpoints"`  // API endpoints ENDPOINT_FLAGS left mounted
+}
+
+// getCapabilities lists the properties, fields and endpoints this deployment
+// serves.
+func getCapabilities(c *gin.Context) {
+	fields := []string{}
+	for name := range jsonFields(reflect.TypeOf(Classification{})) {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	render(c, http.StatusOK, capabilities{Properties: propertyNames(), Fields: fields, Sequences: sequenceNames(), Aliases: enabledAliases(), Endpoints: endpointNames(enabledEndpoints)})
+}
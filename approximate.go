@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// approximation is the body of GET /api/approximate.
+type approximation struct {
+	Value          string   `json:"value"` // As given, so no float rounding hides what was approximated
+	MaxDenominator int      `json:"max_denominator"`
+	Numerator      int      `json:"numerator"` // Carries the sign
+	Denominator    int      `json:"denominator"`
+	Fraction       string   `json:"fraction"`
+	Error          float64  `json:"error"` // |fraction - value|
+	IsExact        bool     `json:"is_exact"`
+	Convergents    []string `json:"convergents"` // Continued-fraction convergents with denominators in range
+}
+
+// errNotDecimal is returned for a value that isn't a plain decimal.
+var errNotDecimal = errors.New("value must be a decimal number such as 3.14159 or -2.5e-3")
+
+// approximate finds the fraction closest to a decimal among those with a
+// denominator of at most max_denominator.
+func approximate(c *gin.Context) {
+	raw := strings.TrimSpace(c.Query("value"))
+	if len(raw) > cfg.MaxNumberLength {
+		respondError(c, http.StatusBadRequest, truncateEcho(raw, cfg.MaxNumberLength), errTooLong.Error())
+		return
+	}
+	value, err := parseDecimal(raw)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, raw, err.Error())
+		return
+	}
+	recordInput(c, "value", raw)
+	maxDen, ok := intQuery(c, "max_denominator", 1000)
+	if !ok {
+		return
+	}
+
+	// The numerator is at most (|value| + 1) × denominator, which must fit in an int
+	abs := new(big.Rat).Abs(value)
+	whole := new(big.Int).Quo(abs.Num(), abs.Denom())
+	if !whole.IsInt64() || whole.Int64() == math.MaxInt64 {
+		respondError(c, http.StatusBadRequest, raw, errOutOfRange.Error())
+		return
+	}
+	limit := math.MaxInt / (int(whole.Int64()) + 1)
+	if maxDen < 1 || maxDen > limit {
+		respondError(c, http.StatusBadRequest, c.Query("max_denominator"), fmt.Sprintf("max_denominator must be between 1 and %d for this value", limit))
+		return
+	}
+
+	best, convergents := bestRational(value, int64(maxDen))
+	diff, _ := new(big.Rat).Sub(best, value).Float64()
+	render(c, http.StatusOK, approximation{
+		Value:          raw,
+		MaxDenominator: maxDen,
+		Numerator:      int(best.Num().Int64()),
+		Denominator:    int(best.Denom().Int64()),
+		Fraction:       best.String(),
+		Error:          math.Abs(diff),
+		IsExact:        best.Cmp(value) == 0,
+		Convergents:    convergents,
+	})
+}
+
+// parseDecimal parses a decimal with an optional fraction and exponent into
+// an exact rational, so 0.1 is 1/10 rather than the float nearest it. The
+// exponent is limited to ±EXACT_MAX_DIGITS.
+func parseDecimal(raw string) (*big.Rat, error) {
+	m := exactDecimal.FindStringSubmatch(raw)
+	if m == nil || m[2]+m[3] == "" {
+		return nil, errNotDecimal
+	}
+	scale := -len(m[3])
+	if m[4] != "" {
+		exp, err := strconv.Atoi(m[4])
+		if err != nil || exp < -cfg.ExactMaxDigits || exp > cfg.ExactMaxDigits {
+			return nil, errTooManyDigits
+		}
+		scale += exp
+	}
+	num, _ := new(big.Int).SetString("0"+m[2]+m[3], 10)
+	if m[1] == "-" {
+		num.Neg(num)
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(scale, -scale))), nil)
+	if scale >= 0 {
+		return new(big.Rat).SetInt(num.Mul(num, pow)), nil
+	}
+	return new(big.Rat).SetFrac(num, pow), nil
+}
+
+// bestRational returns the fraction nearest v with a denominator of at most
+// maxDen, and v's convergents within that bound. The answer is either the
+// last convergent in range or the semiconvergent between it and the next,
+// p(k-1) + m·p(k) over q(k-1) + m·q(k) with m as large as the bound allows:
+// for π to 5 places and 100 that is 311/99, not the convergent 22/7. Ties
+// go to the convergent, the smaller denominator.
+func bestRational(v *big.Rat, maxDen int64) (*big.Rat, []string) {
+	negative := v.Sign() < 0
+	num, den := new(big.Int).Abs(v.Num()), new(big.Int).Set(v.Denom())
+	bound := big.NewInt(maxDen)
+
+	// pPrev/qPrev and p/q are convergents k-1 and k, seeded with the formal 0/1 and 1/0
+	pPrev, qPrev, p, q := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	convergents := []string{}
+	best := new(big.Rat)
+	for den.Sign() != 0 {
+		a := new(big.Int)
+		a.QuoRem(num, den, num) // num becomes the remainder
+		num, den = den, num
+		pNext := new(big.Int).Add(pPrev, new(big.Int).Mul(a, p))
+		qNext := new(big.Int).Add(qPrev, new(big.Int).Mul(a, q))
+		if qNext.Cmp(bound) > 0 {
+			// m = floor((maxDen - q(k-1)) / q(k)), which is below a
+			m := new(big.Int).Quo(new(big.Int).Sub(bound, qPrev), q)
+			semi := new(big.Rat).SetFrac(new(big.Int).Add(pPrev, new(big.Int).Mul(m, p)), new(big.Int).Add(qPrev, new(big.Int).Mul(m, q)))
+			if m.Sign() > 0 && closer(semi, best, new(big.Rat).Abs(v)) {
+				best = semi
+			}
+			break
+		}
+		pPrev, qPrev, p, q = p, q, pNext, qNext
+		best = new(big.Rat).SetFrac(p, q)
+		convergents = append(convergents, signedFraction(best, negative))
+	}
+	if negative {
+		best.Neg(best)
+	}
+	return best, convergents
+}
+
+// closer reports whether a is strictly nearer v than b.
+func closer(a, b, v *big.Rat) bool {
+	da := new(big.Rat).Abs(new(big.Rat).Sub(a, v))
+	db := new(big.Rat).Abs(new(big.Rat).Sub(b, v))
+	return da.Cmp(db) < 0
+}
+
+// signedFraction writes r as "p/q", negated when negative is set.
+func signedFraction(r *big.Rat, negative bool) string {
+	if negative {
+		return new(big.Rat).Neg(r).String()
+	}
+	return r.String()
+}
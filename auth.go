@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authExemptPaths stay reachable without a key, so probes keep working.
+var authExemptPaths = map[string]bool{"/healthz": true, "/readyz": true}
+
+// apiKeys holds the SHA-256 of every accepted key. Empty means auth is off.
+var apiKeys = loadAPIKeys(cfg.APIKeys, cfg.APIKeysFile)
+
+// loadAPIKeys combines the keys from API_KEYS and API_KEYS_FILE (one key per
+// line, # comments allowed). An unreadable file is fatal rather than leaving
+// the API open by accident.
+func loadAPIKeys(keys []string, file string) [][sha256.Size]byte {
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			log.Fatalf("Failed to read API_KEYS_FILE: %v", err)
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+				keys = append(keys, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Fatalf("Failed to read API_KEYS_FILE: %v", err)
+		}
+	}
+
+	digests := make([][sha256.Size]byte, len(keys))
+	for i, key := range keys {
+		digests[i] = sha256.Sum256([]byte(key))
+	}
+	if len(digests) > 0 {
+		log.Printf("API key auth enabled with %d key(s)", len(digests))
+	}
+	return digests
+}
+
+// validAPIKey reports whether key is configured. Keys are compared as hashes
+// in constant time, and every key is checked, so timing reveals nothing.
+func validAPIKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	digest := sha256.Sum256([]byte(key))
+	match := 0
+	for _, known := range apiKeys {
+		match |= subtle.ConstantTimeCompare(digest[:], known[:])
+	}
+	return match == 1
+}
+
+// presentedKey extracts the key from "Authorization: Bearer <key>" or, failing
+// that, from X-API-Key.
+func presentedKey(authorization, apiKey string) string {
+	if scheme, token, ok := strings.Cut(authorization, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return strings.TrimSpace(apiKey)
+}
+
+// requireAPIKey returns 401 for requests without a valid key. It does nothing
+// when no keys are configured.
+func requireAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(apiKeys) == 0 || authExemptPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+		if !validAPIKey(presentedKey(c.GetHeader("Authorization"), c.GetHeader("X-API-Key"))) {
+			c.Header("WWW-Authenticate", `Bearer realm="num_class_api"`)
+			render(c, http.StatusUnauthorized, gin.H{
+				"error":   true,
+				"message": "a valid API key is required in the Authorization: Bearer or X-API-Key header",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// authUnaryInterceptor applies the same check to unary gRPC calls.
+func authUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := checkGRPCKey(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStreamInterceptor applies the same check to streaming gRPC calls.
+func authStreamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := checkGRPCKey(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// checkGRPCKey reads the key from the authorization or x-api-key metadata.
+func checkGRPCKey(ctx context.Context) error {
+	if len(apiKeys) == 0 {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if !validAPIKey(presentedKey(firstMetadata(md, "authorization"), firstMetadata(md, "x-api-key"))) {
+		return status.Error(codes.Unauthenticated, "a valid API key is required")
+	}
+	return nil
+}
+
+// firstMetadata returns the first value of a metadata key, or "".
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// benchNumbers mixes a small number, a highly composite one and a large
+// prime, so the divisor loops run both short and to √n.
+var benchNumbers = []int{28, 720720, 2147483647}
+
+func BenchmarkAliquotSum(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, n := range benchNumbers {
+			aliquotSum(n)
+		}
+	}
+}
+
+func BenchmarkPrimesUpTo(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		primesUpTo(1_000_000)
+	}
+}
+
+func BenchmarkClassify(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, n := range benchNumbers {
+			classify(n, classifyOptions{})
+		}
+	}
+}
+
+func BenchmarkClassifyBig(b *testing.B) {
+	n, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10) // 2^127 - 1
+	for i := 0; i < b.N; i++ {
+		classifyBig(n)
+	}
+}
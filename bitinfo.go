@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/adidazbot/num_class_api/numclass"
+	"github.com/gin-gonic/gin"
+)
+
+// bitInfo is defined in the numclass package.
+type bitInfo = numclass.BitInfo
+
+// bitWidths are the register widths ?bit_width= accepts.
+var bitWidths = []int{8, 16, 32, 64}
+
+// bitWidthQuery reads ?bit_width=, defaulting to 64. number must fit the
+// width as either a signed or an unsigned value, so -128 and 255 both fit 8.
+func bitWidthQuery(c *gin.Context, number int) (int, bool) {
+	width, ok := intQuery(c, "bit_width", 64)
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case !slices.Contains(bitWidths, width):
+		respondError(c, http.StatusBadRequest, c.Query("bit_width"), fmt.Sprintf("bit_width must be one of %v", bitWidths))
+		return 0, false
+	case width < 64 && (number < -(1<<(width-1)) || number > 1<<width-1):
+		respondError(c, http.StatusBadRequest, strconv.Itoa(number), fmt.Sprintf("number does not fit in %d bits", width))
+		return 0, false
+	}
+	return width, true
+}
+
+// describeBits reports the width-bit pattern of n. Negatives are taken in
+// two's complement, so -1 at width 8 is 11111111.
+func describeBits(n, width int) *bitInfo {
+	pattern := uint64(n)
+	if width < 64 {
+		pattern &= 1<<width - 1
+	}
+	info := &bitInfo{
+		Width:         width,
+		Binary:        fmt.Sprintf("%0*b", width, pattern),
+		Popcount:      bits.OnesCount64(pattern),
+		BitLength:     bits.Len64(pattern),
+		TrailingZeros: min(bits.TrailingZeros64(pattern), width),
+		GrayCode:      pattern ^ pattern>>1,
+		ReversedBits:  bits.Reverse64(pattern) >> (64 - width),
+	}
+	info.LeadingZeros = width - info.BitLength
+	return info
+}
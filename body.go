@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// limitBody caps the size of every request body at maxBytes.
+func limitBody(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+		c.Next()
+	}
+}
+
+// decodeJSONBody decodes the request body into v, writing an error response
+// and returning false if the body is too large or malformed.
+func decodeJSONBody(c *gin.Context, v interface{}) bool {
+	err := json.NewDecoder(c.Request.Body).Decode(v)
+	if err == nil {
+		return true
+	}
+
+	// Return 413 Payload Too Large when the body exceeds the configured limit
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		render(c, http.StatusRequestEntityTooLarge, gin.H{
+			"error":   true,
+			"message": "request body too large",
+		})
+		return false
+	}
+
+	// Return 400 Bad Request for anything that isn't valid JSON
+	render(c, http.StatusBadRequest, gin.H{
+		"error":   true,
+		"message": "invalid JSON body",
+	})
+	return false
+}
@@ -0,0 +1,520 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/adidazbot/num_class_api/numclass"
+	"github.com/gin-gonic/gin"
+)
+
+// Classification is the result of classifying a number; it lives in the
+// numclass package so Go clients can share it.
+type Classification = numclass.Classification
+
+// naturalOnlyFields are the properties defined only for positive integers.
+// Negative numbers report them as false and list them in Undefined, rather
+// than claiming e.g. that -7 has been checked and found not prime.
+var naturalOnlyFields = []string{
+	"is_prime", "is_perfect", "is_practical", "is_carmichael", "is_sphenic", "is_self_number", "is_achilles",
+	"is_circular_prime", "is_primorial", "is_duffinian", "is_hoax", "is_keith", "digit_economy",
+	"is_highly_composite",
+}
+
+// signOf names the sign of n.
+func signOf(n int) string {
+	switch {
+	case n < 0:
+		return "negative"
+	case n == 0:
+		return "zero"
+	}
+	return "positive"
+}
+
+// classifyOptions tweaks what classify computes and reports.
+type classifyOptions struct {
+	Debug        bool            // Record per-step timings
+	PowerBase    int             // Also check for powers of this base when >= 2
+	DigitBase    int             // Base for the pandigital checks, 10 when unset
+	Explain      bool            // Add a reasoning string for each property
+	Sequences    bool            // List the named sequences the number belongs to
+	Verbose      bool            // Add the full verbose details
+	FactorFormat string          // Also write the verbose factorization out: plain or latex
+	Grouping     *digitGrouping  // Add the digit-grouped form when set
+	BitWidth     int             // Add bit_info at this width when set
+	Score        bool            // Add the weighted interesting_score
+	Digits       bool            // Add digit_stats
+	FactTypes    []string        // Fun-fact categories; just math when empty
+	Fields       fieldMask       // Skip checks whose fields are masked out
+	Context      context.Context // Cancels an in-flight fun-fact fetch; nil never cancels
+}
+
+// contextOrBackground returns the options' context, defaulting to Background.
+func (o classifyOptions) contextOrBackground() context.Context {
+	if o.Context == nil {
+		return context.Background()
+	}
+	return o.Context
+}
+
+// classify computes every property of a number, including its fun fact, or
+// returns it from PRECOMPUTE_RANGE.
+func classify(number int, opts classifyOptions) Classification {
+	result, _ := classifyCached(number, opts)
+	return result
+}
+
+// classifyCached is classify, also reporting whether the result was served
+// from a cache: the PRECOMPUTE_RANGE table, or funFactCache for every fun
+// fact asked for. A freshly computed result without fun facts is a miss.
+// Debug results, which never use the table, report it in cached too.
+func classifyCached(number int, opts classifyOptions) (Classification, bool) {
+	if result, ok := precomputedResult(number, opts); ok {
+		return result, true
+	}
+	var sw *stopwatch
+	if opts.Debug {
+		sw = newStopwatch()
+	}
+	result := Classification{Number: number}
+	factors := lazyFactors(number) // Shared by the factorization-based checks
+
+	// Determine number properties, skipping any the field mask leaves out
+	want := func(names ...string) bool { return anyEnabled(names...) && opts.Fields.wants(names...) }
+	check := want // Whether to run the checks behind the named fields
+	if cfg.FunFactMode == funFactStatic && want("fun_fact", "fun_facts", "fun_fact_source", "fun_fact_error") {
+		check = anyEnabled // The static fact draws on every enabled property
+	}
+	if check("is_prime") {
+		sw.time("prime_check", func() { result.IsPrime = isPrime(number) })
+	}
+	if check("is_perfect", "abundance") && number > 0 {
+		sw.time("perfect_check", func() {
+			sum, ok := aliquotSumOf(number, factors) // One sum for both fields
+			if !ok {
+				return // null rather than a wrapped value
+			}
+			abundance := sum - number
+			result.IsPerfect, result.Abundance = abundance == 0, &abundance
+		})
+	}
+	if check("is_practical") {
+		sw.time("practical_check", func() { result.IsPractical = practical(number, factors) })
+	}
+	if check("is_carmichael") {
+		sw.time("carmichael_check", func() { result.IsCarmichael = carmichael(number, factors) })
+	}
+	if check("is_sphenic") {
+		sw.time("sphenic_check", func() { result.IsSphenic = sphenic(number, factors) })
+	}
+	if check("is_achilles") {
+		sw.time("achilles_check", func() { result.IsAchilles = achilles(number, factors) })
+	}
+	if check("is_circular_prime") {
+		sw.time("circular_prime_check", func() { result.IsCircularPrime = isCircularPrime(number) })
+	}
+	if check("is_primorial") {
+		sw.time("primorial_check", func() { result.IsPrimorial = isPrimorial(number) })
+	}
+	if check("is_highly_composite") {
+		sw.time("highly_composite_check", func() { result.IsHighlyComposite = isHighlyComposite(number) })
+	}
+	if check("is_duffinian") {
+		sw.time("duffinian_check", func() { result.IsDuffinian = duffinian(number, factors) })
+	}
+	if check("is_hoax") {
+		sw.time("hoax_check", func() { result.IsHoax = hoax(number, factors) })
+	}
+	if check("is_keith") {
+		sw.time("keith_check", func() { result.IsKeith = isKeith(number) })
+	}
+	if check("digit_economy") {
+		sw.time("digit_economy", func() {
+			if economy, ok := digitEconomyOf(number, factors); ok {
+				result.DigitEconomy = &economy
+			}
+		})
+	}
+	sw.time("power_check", func() {
+		if check("is_power_of_two") {
+			result.IsPowerOfTwo = isPowerOfTwo(number)
+		}
+		if opts.PowerBase >= 2 {
+			isPower := isPowerOf(number, opts.PowerBase)
+			result.IsPowerOf = &isPower
+			result.PowerBase = opts.PowerBase
+		}
+	})
+	sw.time("figurate_check", func() {
+		if check("is_triangular", "triangular_index") {
+			if k, ok := triangularIndex(number); ok {
+				result.IsTriangular, result.TriangularIndex = true, &k
+			}
+		}
+		if check("is_square", "square_index") {
+			if k, ok := squareIndex(number); ok {
+				result.IsSquare, result.SquareIndex = true, &k
+			}
+		}
+	})
+	if check("is_evil", "is_odious", "is_binary_palindrome") {
+		sw.time("binary_check", func() {
+			result.IsEvil = isEvil(number)
+			result.IsOdious = !result.IsEvil
+			result.IsBinaryPalindrome = isBinaryPalindrome(number)
+		})
+	}
+	sw.time("digit_properties", func() {
+		result.Properties = []string{}
+		if !disabledProperties["armstrong"] && isArmstrong(number) {
+			result.Properties = append(result.Properties, "armstrong")
+		}
+		if number%2 == 0 && !disabledProperties["even"] {
+			result.Properties = append(result.Properties, "even")
+		} else if number%2 != 0 && !disabledProperties["odd"] {
+			result.Properties = append(result.Properties, "odd")
+		}
+		result.DigitSum = digitSum(number)
+		if check("is_self_number") {
+			result.IsSelfNumber = isSelfNumber(number)
+		}
+		base := 10
+		if opts.DigitBase != 0 {
+			base, result.PandigitalBase = opts.DigitBase, opts.DigitBase
+		}
+		if check("is_pandigital") {
+			result.IsPandigital = isPandigital(number, base, false)
+		}
+		if check("is_zeroless_pandigital") {
+			result.IsZerolessPandigital = isPandigital(number, base, true)
+		}
+		if check("is_undulating") {
+			result.IsUndulating = isUndulating(number)
+		}
+		if check("is_disarium") {
+			result.IsDisarium = isDisarium(number)
+		}
+		if reversed, ok := reverseDigits(number); ok {
+			result.Reversed = &reversed
+		}
+	})
+	result.Sign, result.Undefined = signOf(number), []string{}
+	if number < 0 {
+		for _, name := range naturalOnlyFields {
+			if want(name) {
+				result.Undefined = append(result.Undefined, name)
+			}
+		}
+	}
+	cached := false
+	switch {
+	case len(opts.FactTypes) > 1 && want("fun_facts", "fun_fact", "fun_fact_source", "fun_fact_error"):
+		sw.time("fun_fact_fetch", func() {
+			var origins map[string]factOrigin
+			result.FunFacts, origins = funFactsOf(opts.contextOrBackground(), result, opts.FactTypes)
+			result.FunFact = result.FunFacts[opts.FactTypes[0]] // For clients that only read fun_fact
+			origins[opts.FactTypes[0]].apply(&result)
+			cached = true
+			for _, origin := range origins {
+				cached = cached && origin.source == factFromCache
+			}
+		})
+	case want("fun_fact", "fun_fact_source", "fun_fact_error"):
+		factType := factTypes[0]
+		if len(opts.FactTypes) == 1 {
+			factType = opts.FactTypes[0]
+		}
+		sw.time("fun_fact_fetch", func() {
+			var origin factOrigin
+			result.FunFact, origin = funFactOf(opts.contextOrBackground(), result, factType)
+			origin.apply(&result)
+			cached = origin.source == factFromCache
+		})
+	}
+	if opts.Grouping != nil {
+		result.Formatted = formatGrouped(number, *opts.Grouping)
+	}
+	if opts.Explain && want("explanations") {
+		sw.time("explanations", func() { result.Explanations = explain(result, factors) })
+	}
+	if opts.Sequences && want("sequences") {
+		sw.time("sequences", func() { result.Sequences = sequencesOf(number) })
+	}
+	if opts.Verbose && want("verbose") {
+		sw.time("verbose_details", func() { result.Verbose = describe(number, opts.FactorFormat) })
+	}
+	if opts.BitWidth > 0 && want("bit_info") {
+		result.BitInfo = describeBits(number, opts.BitWidth)
+	}
+	if opts.Score && want("interesting_score") {
+		sw.time("interesting_score", func() {
+			score := interestingScore(number)
+			result.InterestingScore = &score
+		})
+	}
+	if opts.Digits && want("digit_stats") {
+		result.DigitStats = describeDigits(number)
+	}
+
+	result.Timings = sw.result()
+	if opts.Debug {
+		result.Cached = &cached
+	}
+	return result, cached
+}
+
+// classifyNumber handles number classification and returns JSON response.
+func classifyNumber(c *gin.Context) {
+	precision, ok := precisionQuery(c)
+	if !ok {
+		return
+	}
+
+	// Get number from query params; exact mode takes numbers of any size
+	var number int
+	if precision == precisionExact {
+		exact, ok := exactNumberQuery(c)
+		if !ok {
+			return
+		}
+		if !exact.IsInt64() {
+			setCacheHeader(c, false)
+			renderBigClassification(c, exact)
+			return
+		}
+		number = int(exact.Int64())
+	} else if number, ok = numberQuery(c); !ok {
+		return
+	}
+
+	opts := classifyOptions{
+		Context:   c.Request.Context(), // Stop the fun-fact fetch if the client goes away
+		Debug:     boolQuery(c, "debug"),
+		Verbose:   boolQuery(c, "verbose"),
+		Explain:   boolQuery(c, "explain"),
+		Sequences: boolQuery(c, "sequences"),
+		Score:     boolQuery(c, "interesting_score"),
+		Digits:    boolQuery(c, "digit_stats"),
+	}
+	if _, present := c.GetQuery("power_base"); present {
+		base, ok := intQuery(c, "power_base", 0)
+		if !ok {
+			return
+		}
+		if base < 2 {
+			respondError(c, http.StatusBadRequest, c.Query("power_base"), "power_base must be at least 2")
+			return
+		}
+		opts.PowerBase = base
+	}
+	if _, present := c.GetQuery("pandigital_base"); present {
+		base, ok := intQuery(c, "pandigital_base", 0)
+		if !ok {
+			return
+		}
+		if base < 2 || base > guessBaseMaxBase {
+			respondError(c, http.StatusBadRequest, c.Query("pandigital_base"), "pandigital_base must be between 2 and 36")
+			return
+		}
+		opts.DigitBase = base
+	}
+	if opts.Verbose {
+		if opts.FactorFormat, ok = factorFormatQuery(c); !ok {
+			return
+		}
+	}
+	if boolQuery(c, "formatted") {
+		grouping, ok := groupingQuery(c)
+		if !ok {
+			return
+		}
+		opts.Grouping = &grouping
+	}
+	if opts.FactTypes, ok = factTypesQuery(c); !ok {
+		return
+	}
+	if boolQuery(c, "bit_info") {
+		width, ok := bitWidthQuery(c, number)
+		if !ok {
+			return
+		}
+		opts.BitWidth = width
+	}
+
+	mask, ok := fieldsQuery(c, reflect.TypeOf(Classification{}))
+	if !ok {
+		return
+	}
+	mask = degradeMask(c, mask) // Only cheap fields while overloaded
+	opts.Fields = mask
+
+	result, cached, err := coalescedClassify(number, opts)
+	if err != nil {
+		return // Client went away; nothing more can be sent
+	}
+	setCacheHeader(c, cached)
+	if mask == nil {
+		stats.record(result) // Partial results would skew the property percentages
+		mask = enabledFields(reflect.TypeOf(result))
+	}
+
+	// Return successful response
+	renderMasked(c, http.StatusOK, result, mask)
+}
+
+// numberQuery parses the "number" query param, writing a 400 and returning
+// false when it is missing or non-numeric. A missing or empty param uses
+// DEFAULT_NUMBER instead when that is configured.
+func numberQuery(c *gin.Context) (int, bool) {
+	if cfg.DefaultNumber != nil && strings.TrimSpace(c.Query("number")) == "" {
+		recordInput(c, "number", *cfg.DefaultNumber)
+		return *cfg.DefaultNumber, true
+	}
+	return numberParam(c, "number")
+}
+
+// numberParam parses a numeric query param the same way as "number",
+// converting a non-integer as ?rounding= says, or refusing it in strict mode.
+func numberParam(c *gin.Context, key string) (int, bool) {
+	mode, ok := roundingQuery(c)
+	if !ok {
+		return 0, false
+	}
+	numberStr := c.Query(key)
+	var number int
+	var rounded bool
+	var err error
+	if strictQuery(c) {
+		number, err = parseStrictNumber(numberStr)
+	} else {
+		number, rounded, err = parseRoundedNumber(numberStr, mode)
+	}
+	if err != nil {
+		if errors.Is(err, errTooLong) {
+			numberStr = truncateEcho(numberStr, cfg.MaxNumberLength) // Don't echo the whole payload
+		}
+		// Return 400 Bad Request for invalid input (non-numeric)
+		respondError(c, http.StatusBadRequest, numberStr, err.Error())
+		return 0, false
+	}
+	recordInput(c, key, number)
+	if rounded {
+		recordRounding(c, key, numberStr, mode)
+	}
+	return number, true
+}
+
+// strictQuery reports whether the request refuses non-integers: ?strict=
+// when it is given, STRICT_INTEGER otherwise.
+func strictQuery(c *gin.Context) bool {
+	if _, present := c.GetQuery("strict"); present {
+		return boolQuery(c, "strict")
+	}
+	return cfg.StrictInteger
+}
+
+// boolQuery reports whether a query param is set to a true value ("true", "1", ...).
+func boolQuery(c *gin.Context, key string) bool {
+	raw, present := c.GetQuery(key)
+	v, err := strconv.ParseBool(raw)
+	if present {
+		recordInput(c, key, err == nil && v)
+	}
+	return err == nil && v
+}
+
+// Errors returned by parseNumber; their text is sent back to the client.
+var (
+	errNotNumeric = errors.New("number must be numeric")
+	errNotFinite  = errors.New("number must be finite (NaN and Infinity are not supported)")
+	errOutOfRange = errors.New("number is outside the supported integer range")
+	errTooLong    = errors.New("number is too long")
+	errNotInteger = errors.New("number must be an integer; strict mode refuses fractions instead of rounding them")
+)
+
+// parseNumber parses a numeric query value, truncating floats to an integer,
+// or refusing them when STRICT_INTEGER is set.
+func parseNumber(raw string) (int, error) {
+	if cfg.StrictInteger {
+		return parseStrictNumber(raw)
+	}
+	number, _, err := parseRoundedNumber(raw, roundTruncate)
+	return number, err
+}
+
+// parseStrictNumber parses a numeric value exactly, refusing any fraction
+// rather than rounding it away. A decimal or exponent that still denotes an
+// integer is fine ("3.0", "1e3"), and parsing without a float keeps
+// "9007199254740993.0" exact.
+func parseStrictNumber(raw string) (int, error) {
+	n, rounded, err := parseExactNumber(raw, roundTruncate)
+	switch {
+	case errors.Is(err, errTooManyDigits):
+		return 0, errOutOfRange
+	case err != nil:
+		return 0, err
+	case rounded:
+		return 0, errNotInteger
+	case !n.IsInt64():
+		return 0, errOutOfRange
+	}
+	return int(n.Int64()), nil
+}
+
+// parseRoundedNumber parses a numeric query value, converting floats to an
+// integer with the given rounding mode. rounded reports whether there was a
+// fraction to drop.
+func parseRoundedNumber(raw, mode string) (number int, rounded bool, err error) {
+	// Refuse oversized input before spending any time parsing it
+	if len(raw) > cfg.MaxNumberLength {
+		return 0, false, errTooLong
+	}
+	raw = strings.TrimSpace(raw)
+
+	// Plain integers parse exactly, without a round trip through float64
+	if number, err := strconv.Atoi(raw); err == nil {
+		return number, false, nil
+	}
+
+	// Try to parse input as a float (to handle floating-point numbers)
+	numberFloat, err := strconv.ParseFloat(raw, 64)
+	if math.IsInf(numberFloat, 0) || math.IsNaN(numberFloat) {
+		// Covers "Inf"/"NaN" literals and overflowing input like "1e400"
+		return 0, false, errNotFinite
+	}
+	if err != nil {
+		return 0, false, errNotNumeric
+	}
+
+	// Reject values that would wrap around when converted to int
+	whole := roundingFuncs[mode](numberFloat)
+	if whole >= math.MaxInt64 || whole < math.MinInt64 {
+		return 0, false, errOutOfRange
+	}
+	return int(whole), whole != numberFloat, nil
+}
+
+// respondError writes the standard error shape, echoing the offending input.
+func respondError(c *gin.Context, status int, input string, message string) {
+	render(c, status, gin.H{
+		"number":  sanitizeEcho(input),
+		"error":   true,
+		"message": message,
+	})
+}
+
+// setCacheHeader sets X-Cache to HIT when classifyCached reported a cached
+// result, and MISS otherwise.
+func setCacheHeader(c *gin.Context, cached bool) {
+	cache := "MISS"
+	if cached {
+		cache = "HIT"
+	}
+	c.Header("X-Cache", cache)
+}
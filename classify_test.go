@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// parsedInput is the part of a response that shows how a number was read.
+type parsedInput struct {
+	Number  any
+	Message string
+	Input   map[string]any
+}
+
+// nearest requests /api/nearest with the given number and extra query. It
+// asks for perfect numbers, whose search is a lookup even for huge inputs.
+func nearest(t *testing.T, number, query string) (int, parsedInput) {
+	t.Helper()
+	w := get(t, "/api/nearest?property=perfect&number="+url.QueryEscape(number)+query)
+	var resp parsedInput
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%s: %v", w.Body, err)
+	}
+	return w.Code, resp
+}
+
+func TestNonNumericInput(t *testing.T) {
+	tests := []struct {
+		number string
+		want   error
+	}{
+		{"true", errNotNumeric},
+		{"abc", errNotNumeric},
+		{"Inf", errNotFinite},
+		{"-Inf", errNotFinite},
+		{"NaN", errNotFinite},
+		{"1e400", errNotFinite},
+		{"1e19", errOutOfRange},
+	}
+	for _, tt := range tests {
+		status, resp := nearest(t, tt.number, "")
+		if status != http.StatusBadRequest || resp.Message != tt.want.Error() {
+			t.Errorf("%s: %d %q, want 400 %q", tt.number, status, resp.Message, tt.want)
+		}
+		if resp.Number != tt.number {
+			t.Errorf("%s: echoed %v", tt.number, resp.Number)
+		}
+	}
+}
+
+// getJSON serves target and decodes its JSON object body.
+func getJSON(t *testing.T, target string) (int, map[string]any) {
+	t.Helper()
+	w := get(t, target)
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%s: %s: %v", target, w.Body, err)
+	}
+	return w.Code, body
+}
+
+func TestPowerBase(t *testing.T) {
+	tests := []struct {
+		query  string
+		status int
+		isPow  any // nil when is_power_of is omitted
+	}{
+		{"number=81&power_base=3", http.StatusOK, true},
+		{"number=1&power_base=7", http.StatusOK, true},
+		{"number=82&power_base=3", http.StatusOK, false},
+		{"number=81", http.StatusOK, nil},
+		{"number=81&power_base=1", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		status, body := getJSON(t, "/api/classify-number?"+tt.query)
+		if status != tt.status || body["is_power_of"] != tt.isPow {
+			t.Errorf("%s: %d, is_power_of %v, want %d, %v", tt.query, status, body["is_power_of"], tt.status, tt.isPow)
+		}
+		if tt.isPow != nil && body["power_base"] == nil {
+			t.Errorf("%s: power_base missing", tt.query)
+		}
+	}
+}
+
+func TestNumberLengthGuard(t *testing.T) {
+	defer func(n int) { cfg.MaxNumberLength = n }(cfg.MaxNumberLength)
+	cfg.MaxNumberLength = 10
+
+	if status, _ := nearest(t, "1234567890", ""); status != http.StatusOK {
+		t.Errorf("10 digits: status %d, want 200", status)
+	}
+	status, resp := nearest(t, strings.Repeat("9", 50), "")
+	if status != http.StatusBadRequest || resp.Message != errTooLong.Error() {
+		t.Fatalf("50 digits: %d %q, want 400 %q", status, resp.Message, errTooLong)
+	}
+	if want := strings.Repeat("9", 10) + "..."; resp.Number != want {
+		t.Errorf("echo = %v, want %q", resp.Number, want)
+	}
+}
+
+func TestSignAndUndefined(t *testing.T) {
+	var natural []any
+	for _, name := range naturalOnlyFields {
+		natural = append(natural, name)
+	}
+	tests := []struct {
+		query     string
+		sign      string
+		undefined []any
+	}{
+		{"number=-28", "negative", natural},
+		{"number=-28&fields=number,sign,undefined,is_prime", "negative", []any{"is_prime"}},
+		{"number=0", "zero", []any{}},
+		{"number=1", "positive", []any{}},
+	}
+	for _, tt := range tests {
+		status, body := getJSON(t, "/api/classify-number?"+tt.query)
+		if status != http.StatusOK {
+			t.Fatalf("%s: status %d", tt.query, status)
+		}
+		if body["sign"] != tt.sign {
+			t.Errorf("%s: sign %v, want %s", tt.query, body["sign"], tt.sign)
+		}
+		if !slices.Equal(body["undefined"].([]any), tt.undefined) {
+			t.Errorf("%s: undefined %v, want %v", tt.query, body["undefined"], tt.undefined)
+		}
+	}
+
+	// Natural-only checks read false, while digit properties use the magnitude
+	_, body := getJSON(t, "/api/classify-number?number=-28")
+	if body["is_prime"] != false || body["is_perfect"] != false || body["digit_sum"] != float64(10) {
+		t.Errorf("-28: is_prime %v, is_perfect %v, digit_sum %v", body["is_prime"], body["is_perfect"], body["digit_sum"])
+	}
+}
+
+func TestRoundingModes(t *testing.T) {
+	tests := []struct {
+		number, mode string
+		want         float64
+	}{
+		{"2.5", roundTruncate, 2},
+		{"-2.5", roundTruncate, -2},
+		{"2.7", roundTruncate, 2},
+		{"2.5", roundNearest, 3},
+		{"-2.5", roundNearest, -3},
+		{"2.7", roundNearest, 3},
+		{"2.5", roundFloor, 2},
+		{"-2.5", roundFloor, -3},
+		{"2.7", roundFloor, 2},
+		{"2.5", roundCeil, 3},
+		{"-2.5", roundCeil, -2},
+		{"2.7", roundCeil, 3},
+	}
+	for _, tt := range tests {
+		status, resp := nearest(t, tt.number, "&rounding="+tt.mode)
+		if status != http.StatusOK {
+			t.Errorf("%s %s: status %d", tt.number, tt.mode, status)
+			continue
+		}
+		if resp.Input["number"] != tt.want || resp.Input["rounding"] != tt.mode || resp.Input["original_number"] != tt.number {
+			t.Errorf("%s %s: input %v, want %v", tt.number, tt.mode, resp.Input, tt.want)
+		}
+	}
+
+	// Truncation is the default
+	if _, resp := nearest(t, "2.7", ""); resp.Input["number"] != float64(2) || resp.Input["rounding"] != roundTruncate {
+		t.Errorf("default: input %v, want 2 truncated", resp.Input)
+	}
+	if status, _ := nearest(t, "2.5", "&rounding=banker"); status != http.StatusBadRequest {
+		t.Errorf("unknown rounding mode: status %d, want 400", status)
+	}
+	if _, resp := nearest(t, "7", "&rounding=ceil"); resp.Input["rounding"] != nil {
+		t.Errorf("integer input echoed rounding %v", resp.Input["rounding"])
+	}
+}
+
+func TestStrictInteger(t *testing.T) {
+	tests := []struct {
+		number, query string
+		want          int // 0 for a 400
+	}{
+		{"3.9", "&strict=true", 0},
+		{"3.0", "&strict=true", 3},
+		{"1e3", "&strict=true", 1000},
+		{"9007199254740993.0", "&strict=true", 9007199254740993}, // Exact, past float64 precision
+		{"3.9", "", 3},
+		{"3.9", "&strict=false", 3},
+	}
+	check := func(label string) {
+		for _, tt := range tests {
+			status, resp := nearest(t, tt.number, tt.query)
+			switch {
+			case tt.want == 0 && (status != http.StatusBadRequest || resp.Message != errNotInteger.Error()):
+				t.Errorf("%s %s%s: %d %q, want 400 %q", label, tt.number, tt.query, status, resp.Message, errNotInteger)
+			case tt.want != 0 && (status != http.StatusOK || resp.Input["number"] != float64(tt.want)):
+				t.Errorf("%s %s%s: %d, input %v, want %d", label, tt.number, tt.query, status, resp.Input, tt.want)
+			}
+		}
+	}
+	check("default")
+
+	// STRICT_INTEGER flips the default, and ?strict=false still opts out
+	defer func(strict bool) { cfg.StrictInteger = strict }(cfg.StrictInteger)
+	cfg.StrictInteger = true
+	tests[4].want = 0
+	check("STRICT_INTEGER")
+}
+
+func TestStrictQueryValues(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"true", true},
+		{"TRUE", true},
+		{"1", true},
+		{"t", true},
+		{"false", false},
+		{"0", false},
+		{"yes", false}, // Not a strconv.ParseBool spelling
+		{"", false},
+	}
+	for _, tt := range tests {
+		status, resp := nearest(t, "3.5", "&strict="+tt.value)
+		if refused := status == http.StatusBadRequest; refused != tt.want {
+			t.Errorf("strict=%q: status %d, want strict %v", tt.value, status, tt.want)
+		}
+		if !tt.want && resp.Input["strict"] != false {
+			t.Errorf("strict=%q echoed %v, want false", tt.value, resp.Input["strict"])
+		}
+	}
+}
+
+func TestCacheHeader(t *testing.T) {
+	// Static facts are built fresh, so nothing here is a cache hit
+	for _, target := range []string{
+		"/api/classify-number?number=28",
+		"/api/random?min=28&max=28",
+		"/api/classify-expr?expr=4*7",
+		"/api/classify-date?date=2024-01-28",
+	} {
+		if w := get(t, target); w.Header().Get("X-Cache") != "MISS" {
+			t.Errorf("%s: X-Cache %q, want MISS", target, w.Header().Get("X-Cache"))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Package client is a Go client for the Number Classification API. Responses
+// decode into the same numclass types the server encodes them from.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adidazbot/num_class_api/numclass"
+)
+
+// defaultPollInterval is how often ClassifyBatch checks on its job when
+// PollInterval is unset.
+const defaultPollInterval = 500 * time.Millisecond
+
+// Client calls the API at BaseURL. The zero value of every other field is
+// usable, and a Client is safe for concurrent use.
+type Client struct {
+	BaseURL      string        // e.g. "http://localhost:8080", without the /api prefix
+	APIKey       string        // Sent as "Authorization: Bearer" when set
+	HTTPClient   *http.Client  // http.DefaultClient when nil
+	PollInterval time.Duration // How often ClassifyBatch polls its job
+}
+
+// New returns a client for the API at baseURL.
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Error is a non-2xx response from the API.
+type Error struct {
+	StatusCode int
+	Code       string                 // Snake-cased status text, e.g. "bad_request"
+	Message    string                 // The server's explanation
+	Details    map[string]interface{} // Context such as the rejected input
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("num_class_api: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Classify classifies a single number.
+func (c *Client) Classify(ctx context.Context, number int) (numclass.Classification, error) {
+	var result numclass.Classification
+	query := url.Values{"number": {strconv.Itoa(number)}}
+	err := c.do(ctx, http.MethodGet, "/api/classify-number?"+query.Encode(), nil, &result)
+	return result, err
+}
+
+// SubmitJob starts an asynchronous classification of numbers.
+func (c *Client) SubmitJob(ctx context.Context, numbers []int) (numclass.Job, error) {
+	var job numclass.Job
+	err := c.do(ctx, http.MethodPost, "/api/jobs", map[string][]int{"numbers": numbers}, &job)
+	return job, err
+}
+
+// Job reports a job's progress, including its results once it is done.
+func (c *Client) Job(ctx context.Context, id string) (numclass.Job, error) {
+	var job numclass.Job
+	err := c.do(ctx, http.MethodGet, "/api/jobs/"+url.PathEscape(id), nil, &job)
+	return job, err
+}
+
+// ClassifyBatch classifies numbers as one job and waits for it to finish,
+// returning one classification per number, in order. Cancelling ctx stops
+// the wait; the job itself runs to completion on the server.
+func (c *Client) ClassifyBatch(ctx context.Context, numbers []int) ([]numclass.Classification, error) {
+	job, err := c.SubmitJob(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for job.Status != numclass.JobDone {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+		if job, err = c.Job(ctx, job.ID); err != nil {
+			return nil, err
+		}
+	}
+	return job.Results, nil
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response
+// into out, or returns an *Error for a non-2xx status.
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Error-Format", "structured") // One error shape, whatever the server's default
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var envelope struct {
+			Error struct {
+				Code    string                 `json:"code"`
+				Message string                 `json:"message"`
+				Details map[string]interface{} `json:"details"`
+			} `json:"error"`
+		}
+		status := http.StatusText(resp.StatusCode)
+		apiErr := &Error{StatusCode: resp.StatusCode, Code: strings.ReplaceAll(strings.ToLower(status), " ", "_"), Message: status}
+		if json.NewDecoder(resp.Body).Decode(&envelope) == nil && envelope.Error.Message != "" {
+			apiErr.Code, apiErr.Message, apiErr.Details = envelope.Error.Code, envelope.Error.Message, envelope.Error.Details
+		}
+		return apiErr
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adidazbot/num_class_api/numclass"
+)
+
+// newTestClient returns a client for a server running handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := New(srv.URL + "/")
+	c.PollInterval = time.Millisecond
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestClassify(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path != "/api/classify-number" || r.URL.Query().Get("number") != "28":
+			t.Errorf("request to %s", r.URL)
+		case r.Header.Get("Authorization") != "Bearer secret":
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		case r.Header.Get("X-Error-Format") != "structured":
+			t.Errorf("X-Error-Format = %q", r.Header.Get("X-Error-Format"))
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"number": 28, "is_perfect": true, "properties": []string{"even"}})
+	})
+	c.APIKey = "secret"
+
+	got, err := c.Classify(context.Background(), 28)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Number != 28 || !got.IsPerfect || len(got.Properties) != 1 || got.Properties[0] != "even" {
+		t.Errorf("Classify(28) = %+v", got)
+	}
+}
+
+func TestClassifyBatchPolls(t *testing.T) {
+	var polls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/jobs":
+			var body struct{ Numbers []int }
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Numbers) != 2 {
+				t.Errorf("job body = %+v, %v", body, err)
+			}
+			writeJSON(w, http.StatusAccepted, numclass.Job{ID: "j1", Status: numclass.JobPending, Total: 2})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/jobs/j1":
+			if polls.Add(1) < 3 {
+				writeJSON(w, http.StatusOK, numclass.Job{ID: "j1", Status: numclass.JobRunning, Total: 2})
+				return
+			}
+			writeJSON(w, http.StatusOK, numclass.Job{ID: "j1", Status: numclass.JobDone, Total: 2, Completed: 2,
+				Results: []numclass.Classification{{Number: 6, IsPerfect: true}, {Number: 7, IsPrime: true}}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	})
+
+	results, err := c.ClassifyBatch(context.Background(), []int{6, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || !results[0].IsPerfect || !results[1].IsPrime {
+		t.Errorf("ClassifyBatch = %+v", results)
+	}
+	if n := polls.Load(); n != 3 {
+		t.Errorf("polled %d times, want 3", n)
+	}
+}
+
+func TestClassifyBatchCancel(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, numclass.Job{ID: "j1", Status: numclass.JobRunning}) // Never finishes
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	results, err := c.ClassifyBatch(ctx, []int{1})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ClassifyBatch = %v, %v; want context.DeadlineExceeded", results, err)
+	}
+}
+
+func TestStructuredError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error": map[string]interface{}{
+			"code":    "bad_request",
+			"message": "number must be numeric",
+			"details": map[string]interface{}{"number": "abc"},
+		}})
+	})
+
+	_, err := c.Classify(context.Background(), 1)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *Error", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != "bad_request" || apiErr.Message != "number must be numeric" || apiErr.Details["number"] != "abc" {
+		t.Errorf("err = %+v", apiErr)
+	}
+}
+
+func TestUnstructuredError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream down", http.StatusBadGateway) // A proxy's plain-text page
+	})
+
+	_, err := c.Classify(context.Background(), 1)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *Error", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Code != "bad_gateway" || apiErr.Message != "Bad Gateway" {
+		t.Errorf("err = %+v", apiErr)
+	}
+}
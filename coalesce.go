@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// classifyFlight shares one classification between identical concurrent
+// requests, and funFactFlight one Numbers API request between identical
+// concurrent lookups, so a trending number costs one computation and one
+// upstream call however many clients ask at once.
+var classifyFlight, funFactFlight singleflight.Group
+
+// sharedClassification is what classifyFlight hands every waiting caller.
+type sharedClassification struct {
+	result Classification
+	cached bool
+}
+
+// coalescedClassify is classifyCached, shared with any identical call already
+// in progress. Each caller gets its own copy of the result, and the cache
+// status of the call that computed it. A caller whose context ends while it
+// waits gets the context's error instead, and the shared call runs on for the
+// rest. Debug requests are timed on their own and never share.
+func coalescedClassify(number int, opts classifyOptions) (Classification, bool, error) {
+	if opts.Debug {
+		result, cached := classifyCached(number, opts)
+		return result, cached, nil
+	}
+	ctx := opts.contextOrBackground()
+	shared := opts
+	shared.Context = context.WithoutCancel(ctx) // One caller leaving mustn't cancel the rest
+	ch := classifyFlight.DoChan(opts.key(number), func() (interface{}, error) {
+		result, cached := classifyCached(number, shared)
+		return sharedClassification{result, cached}, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Shared {
+			classificationsCoalesced.Inc()
+		}
+		shared := res.Val.(sharedClassification)
+		return shared.result.Clone(), shared.cached, nil
+	case <-ctx.Done():
+		return Classification{}, false, ctx.Err()
+	}
+}
+
+// key identifies the options' result for number, leaving out the context.
+func (o classifyOptions) key(number int) string {
+	grouping := "none"
+	if o.Grouping != nil {
+		grouping = fmt.Sprintf("%+v", *o.Grouping)
+	}
+	return fmt.Sprintf("%d|%t|%d|%d|%t|%t|%t|%s|%s|%d|%t|%t|%v|%v",
+		number, o.Debug, o.PowerBase, o.DigitBase, o.Explain, o.Sequences, o.Verbose, o.FactorFormat, grouping, o.BitWidth, o.Score, o.Digits, o.FactTypes, o.Fields)
+}
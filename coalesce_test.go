@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCoalescedClassifyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	// A large prime, so the shared classification is still running
+	_, _, err := coalescedClassify(999999999999989, classifyOptions{Context: ctx})
+	if err != context.Canceled {
+		t.Errorf("err %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("took %v; a cancelled caller should not wait or recompute", elapsed)
+	}
+}
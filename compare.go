@@ -0,0 +1,51 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// comparison describes the relationship between two numbers.
+type comparison struct {
+	A          int    `json:"a"`
+	B          int    `json:"b"`
+	Larger     string `json:"larger"` // "a", "b" or "equal"
+	Difference int    `json:"difference"`
+	GCD        int    `json:"gcd"`
+	LCM        int    `json:"lcm"`
+	Coprime    bool   `json:"coprime"`
+	ADividesB  bool   `json:"a_divides_b"`
+	BDividesA  bool   `json:"b_divides_a"`
+}
+
+// compareNumbers bundles the common two-number relationships into one call.
+func compareNumbers(c *gin.Context) {
+	a, ok := numberParam(c, "a")
+	if !ok {
+		return
+	}
+	b, ok := numberParam(c, "b")
+	if !ok {
+		return
+	}
+
+	larger := "equal"
+	if a > b {
+		larger = "a"
+	} else if b > a {
+		larger = "b"
+	}
+
+	render(c, http.StatusOK, comparison{
+		A:          a,
+		B:          b,
+		Larger:     larger,
+		Difference: a - b,
+		GCD:        gcd(a, b),
+		LCM:        lcm(a, b),
+		Coprime:    gcd(a, b) == 1,
+		ADividesB:  divides(a, b),
+		BDividesA:  divides(b, a),
+	})
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the server settings read from environment variables.
+type Config struct {
+	Port            string
+	GRPCPort        string        // Port for the gRPC API; "off" disables it
+	MaxBodyBytes    int64         // Upper bound on request body size
+	MaxNumberLength int           // Longest raw "number" string accepted before parsing
+	ExactMaxDigits  int           // Most digits a ?precision=exact number may have
+	DefaultNumber   *int          // Used when "number" is absent or empty; nil keeps the 400
+	ReadTimeout     time.Duration // Time allowed to read a full request
+	WriteTimeout    time.Duration // Time allowed to write a response
+	IdleTimeout     time.Duration // Keep-alive idle time between requests
+
+	ShutdownDrainDelay time.Duration // Time /readyz fails before shutdown begins
+	ShutdownTimeout    time.Duration // Time allowed for in-flight requests to finish
+
+	TLSCertFile string // Serve HTTPS (and HTTP/2) when set with TLSKeyFile
+	TLSKeyFile  string
+	TLSCertDir  string // Directory with an auto-renewed certificate pair
+
+	OTLPEndpoint string // Collector spans are exported to; tracing is a no-op when empty
+	OTLPProtocol string // http/protobuf or grpc
+
+	SearchMaxNumber int           // Largest |n| nearest, scan and filter test against a factored property
+	SearchTimeout   time.Duration // How long one nearest, scan or filter search may run
+
+	NearestMaxDistance      int // How far /api/nearest searches in each direction
+	ScanMaxDistance         int // How many numbers /api/scan checks at most
+	ScanMaxLimit            int // Most matches /api/scan streams per request
+	PrimesMaxRange          int // Widest range /api/primes will sieve
+	PrimesMaxEnd            int // Largest end /api/primes accepts; its base primes sieve up to √end
+	PrimeCountMaxX          int // Largest x /api/prime-count will sieve up to
+	PrimesDefaultPageSize   int
+	PrimesMaxPageSize       int
+	UntouchableMaxBound     int // Largest search bound /api/untouchable will sieve
+	VampireMaxDigits        int // Longest number /api/vampire searches for fangs
+	TwoCubesMaxNumber       int // Largest number /api/sum-of-two-cubes accepts
+	PalindromesMaxRange     int // Widest range /api/palindromes will scan
+	FibMaxN                 int // Largest n accepted by /api/fib
+	PisanoMaxMod            int // Largest mod accepted by /api/pisano; its period takes up to 6·mod steps
+	PrimorialMaxK           int // Largest k accepted by /api/primorial
+	SequenceMaxCount        int // Most terms /api/sequence returns
+	RangePropertiesMaxRange int // Widest range /api/range-properties will cover
+	RangePropertiesMaxEnd   int // Largest end /api/range-properties accepts
+
+	StatsSampleRate  float64 // Fraction of classifications counted by /api/stats
+	StatsExactValues bool    // Also track exact numbers, not just magnitudes
+
+	JobTTL        time.Duration // How long finished jobs and idempotency keys are kept
+	JobMaxNumbers int           // Largest batch accepted by POST /api/jobs
+
+	FilterMaxNumbers int // Largest list accepted by POST /api/filter
+
+	ModClassMaxMod int // Largest mod /api/modclass accepts; it is factored by trial division
+
+	MaxDivisors int // Longest divisor (and prime factor) list in verbose details; 0 is unlimited
+
+	InterestWeights map[string]int // Per-property overrides of the interesting_score weights
+
+	DegradeThreshold int      // In-flight requests above which classifications go lite; 0 disables
+	DegradedFields   []string // Fields served while degraded; parity, sign and digit sum when empty
+
+	FunFactMode        string        // "math" fetches from Numbers API, "static" builds facts locally
+	FunFactMaxInFlight int           // Concurrent outbound fun-fact requests allowed
+	FunFactQueueWait   time.Duration // How long to wait for a free slot before falling back
+	FunFactTimeout     time.Duration // Overall limit for one Numbers API request
+	FunFactIdleConns   int           // Keep-alive connections kept open to Numbers API
+	FunFactIdleTimeout time.Duration // How long an idle keep-alive connection is kept
+	FunFactWarmNumbers []int         // Numbers whose fun facts are fetched ahead of requests
+	FunFactWarmTTL     time.Duration // How long a warmed fact is served; refreshed before then
+
+	PrecomputeRange *[2]int // Numbers classified at startup and served from memory; nil for none
+
+	PprofEnabled bool // Serve /debug/pprof; never enable on a public listener
+
+	EnabledProperties []string        // Registry properties to serve; all of them when empty
+	EndpointFlags     map[string]bool // Per-endpoint on/off switches; "*" sets the default for the rest
+
+	StrictInteger bool // Refuse numbers with a fraction instead of rounding them; ?strict= overrides
+
+	ErrorFormat string // Default error body shape: "legacy" or "structured"
+
+	APIKeys     []string // Accepted API keys; auth is off when none are configured
+	APIKeysFile string   // File with more keys, one per line
+
+	WebhookSecret       string        // HMAC key for the X-Signature-256 callback header
+	WebhookTimeout      time.Duration // Per-attempt timeout for job callbacks
+	WebhookAllowedHosts []string      // Hosts exempt from the internal-address check
+	WebhookMaxAttempts  int           // Delivery attempts before a callback is dead-lettered
+	WebhookRetryBase    time.Duration // Backoff ceiling after the first failed attempt
+	WebhookRetryMax     time.Duration // Largest backoff between attempts
+	WebhookMaxInFlight  int           // Concurrent outbound callback requests allowed
+}
+
+// cfg is the active configuration, loaded once at startup.
+var cfg = loadConfig()
+
+// loadConfig builds a Config from the environment, falling back to defaults.
+func loadConfig() Config {
+	return Config{
+		Port:            envString("PORT", "8080"), // Render assigns a dynamic port
+		GRPCPort:        envString("GRPC_PORT", "9090"),
+		MaxBodyBytes:    envInt64("MAX_BODY_BYTES", 1<<20), // 1 MiB
+		MaxNumberLength: envInt("MAX_NUMBER_LENGTH", 4096),
+		ExactMaxDigits:  envInt("EXACT_MAX_DIGITS", 1000),
+		DefaultNumber:   envOptionalInt("DEFAULT_NUMBER"),
+		ReadTimeout:     envDuration("READ_TIMEOUT", 10*time.Second),
+		WriteTimeout:    envDuration("WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:     envDuration("IDLE_TIMEOUT", 120*time.Second),
+
+		ShutdownDrainDelay: envDuration("SHUTDOWN_DRAIN_DELAY", 5*time.Second),
+		ShutdownTimeout:    envDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+
+		TLSCertFile: os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:  os.Getenv("TLS_KEY_FILE"),
+		TLSCertDir:  os.Getenv("TLS_CERT_DIR"),
+
+		OTLPEndpoint: envString("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")),
+		OTLPProtocol: envChoice("OTEL_EXPORTER_OTLP_PROTOCOL", otlpHTTP, otlpGRPC),
+
+		SearchMaxNumber: envInt("SEARCH_MAX_NUMBER", 1_000_000_000_000),
+		SearchTimeout:   envDuration("SEARCH_TIMEOUT", 10*time.Second),
+
+		NearestMaxDistance:      envInt("NEAREST_MAX_DISTANCE", 10000),
+		ScanMaxDistance:         envInt("SCAN_MAX_DISTANCE", 1_000_000),
+		ScanMaxLimit:            envInt("SCAN_MAX_LIMIT", 100),
+		PrimesMaxRange:          envInt("PRIMES_MAX_RANGE", 10_000_000),
+		PrimesMaxEnd:            envInt("PRIMES_MAX_END", 1_000_000_000_000),
+		PrimeCountMaxX:          envInt("PRIME_COUNT_MAX_X", 100_000_000),
+		PrimesDefaultPageSize:   envInt("PRIMES_DEFAULT_PAGE_SIZE", 100),
+		PrimesMaxPageSize:       envInt("PRIMES_MAX_PAGE_SIZE", 1000),
+		UntouchableMaxBound:     envInt("UNTOUCHABLE_MAX_BOUND", 1_000_000),
+		VampireMaxDigits:        envInt("VAMPIRE_MAX_DIGITS", 14),
+		TwoCubesMaxNumber:       envInt("TWO_CUBES_MAX_NUMBER", 1_000_000_000_000_000),
+		PalindromesMaxRange:     envInt("PALINDROMES_MAX_RANGE", 100_000_000),
+		FibMaxN:                 envInt("FIB_MAX_N", 10000),
+		PisanoMaxMod:            envInt("PISANO_MAX_MOD", 10_000_000),
+		PrimorialMaxK:           envInt("PRIMORIAL_MAX_K", 10000),
+		SequenceMaxCount:        envInt("SEQUENCE_MAX_COUNT", 1000),
+		RangePropertiesMaxRange: envInt("RANGE_PROPERTIES_MAX_RANGE", 65_536),
+		RangePropertiesMaxEnd:   envInt("RANGE_PROPERTIES_MAX_END", 10_000_000),
+
+		StatsSampleRate:  envFloat("STATS_SAMPLE_RATE", 1),
+		StatsExactValues: envBool("STATS_EXACT_VALUES", false),
+
+		JobTTL:        envDuration("JOB_TTL", time.Hour),
+		JobMaxNumbers: envInt("JOB_MAX_NUMBERS", 10000),
+
+		FilterMaxNumbers: envInt("FILTER_MAX_NUMBERS", 10000),
+
+		ModClassMaxMod: envInt("MODCLASS_MAX_MOD", 1_000_000_000_000),
+
+		MaxDivisors: envInt("MAX_DIVISORS", 1000),
+
+		InterestWeights: envIntMap("INTEREST_WEIGHTS"),
+
+		DegradeThreshold: envInt("DEGRADE_THRESHOLD", 0),
+		DegradedFields:   envList("DEGRADED_FIELDS"),
+
+		FunFactMode:        envChoice("FUN_FACT_MODE", funFactMath, funFactStatic),
+		FunFactMaxInFlight: envInt("FUN_FACT_MAX_IN_FLIGHT", 32),
+		FunFactQueueWait:   envDuration("FUN_FACT_QUEUE_WAIT", 100*time.Millisecond),
+		FunFactTimeout:     envDuration("FUN_FACT_TIMEOUT", 2*time.Second),
+		FunFactIdleConns:   envInt("FUN_FACT_IDLE_CONNS", 32),
+		FunFactIdleTimeout: envDuration("FUN_FACT_IDLE_TIMEOUT", 90*time.Second),
+		FunFactWarmNumbers: envIntList("FUN_FACT_WARM_NUMBERS"),
+		FunFactWarmTTL:     envDuration("FUN_FACT_WARM_TTL", time.Hour),
+
+		PrecomputeRange: envIntRange("PRECOMPUTE_RANGE"),
+
+		PprofEnabled: envBool("PPROF_ENABLED", false),
+
+		EnabledProperties: envList("ENABLED_PROPERTIES"),
+		EndpointFlags:     envFlagMap("ENDPOINT_FLAGS"),
+
+		StrictInteger: envBool("STRICT_INTEGER", false),
+
+		ErrorFormat: envChoice("ERROR_FORMAT", errorFormatLegacy, errorFormatStructured),
+
+		APIKeys:     envList("API_KEYS"),
+		APIKeysFile: os.Getenv("API_KEYS_FILE"),
+
+		WebhookSecret:       os.Getenv("WEBHOOK_SECRET"),
+		WebhookTimeout:      envDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookAllowedHosts: envList("WEBHOOK_ALLOWED_HOSTS"),
+		WebhookMaxAttempts:  envInt("WEBHOOK_MAX_ATTEMPTS", 3),
+		WebhookRetryBase:    envDuration("WEBHOOK_RETRY_BASE", time.Second),
+		WebhookRetryMax:     envDuration("WEBHOOK_RETRY_MAX", time.Minute),
+		WebhookMaxInFlight:  envInt("WEBHOOK_MAX_IN_FLIGHT", 16),
+	}
+}
+
+// envString returns the value of an environment variable or a default.
+func envString(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// envInt64 parses an integer environment variable or returns a default.
+func envInt64(key string, def int64) int64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+// envInt parses an int environment variable or returns a default.
+func envInt(key string, def int) int {
+	return int(envInt64(key, int64(def)))
+}
+
+// envOptionalInt parses an integer environment variable, returning nil when
+// it is unset or invalid.
+func envOptionalInt(key string) *int {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, leaving it unset", key, v)
+		return nil
+	}
+	return &n
+}
+
+// envFloat parses a float environment variable or returns a default.
+func envFloat(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %g", key, v, def)
+		return def
+	}
+	return f
+}
+
+// envBool parses a boolean environment variable ("true", "1", ...) or returns a default.
+func envBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %t", key, v, def)
+		return def
+	}
+	return b
+}
+
+// envChoice returns an environment variable if it is one of choices, or the
+// first choice (the default) otherwise.
+func envChoice(key string, choices ...string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		return choices[0]
+	}
+	for _, choice := range choices {
+		if strings.EqualFold(v, choice) {
+			return choice
+		}
+	}
+	log.Printf("Invalid %s=%q, using default %s", key, v, choices[0])
+	return choices[0]
+}
+
+// envList splits a comma-separated environment variable, dropping blanks.
+func envList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// envIntList parses a comma-separated list of integers, skipping invalid ones.
+func envIntList(key string) []int {
+	var values []int
+	for _, item := range envList(key) {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			log.Printf("Invalid %s entry %q, skipping it", key, item)
+			continue
+		}
+		values = append(values, n)
+	}
+	return values
+}
+
+// envIntMap parses comma-separated name=integer pairs, skipping invalid ones.
+func envIntMap(key string) map[string]int {
+	values := map[string]int{}
+	for _, item := range envList(key) {
+		name, raw, _ := strings.Cut(item, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || strings.TrimSpace(name) == "" {
+			log.Printf("Invalid %s entry %q, skipping it", key, item)
+			continue
+		}
+		values[strings.TrimSpace(name)] = n
+	}
+	return values
+}
+
+// envFlagMap parses comma-separated name=on/off pairs, such as
+// "vampire=off,*=on". Values may also be anything strconv.ParseBool accepts;
+// invalid entries are logged and skipped.
+func envFlagMap(key string) map[string]bool {
+	flags := map[string]bool{}
+	for _, item := range envList(key) {
+		name, raw, _ := strings.Cut(item, "=")
+		name, raw = strings.ToLower(strings.TrimSpace(name)), strings.ToLower(strings.TrimSpace(raw))
+		on, err := strconv.ParseBool(raw)
+		switch {
+		case raw == "on":
+			on, err = true, nil
+		case raw == "off":
+			on, err = false, nil
+		}
+		if err != nil || name == "" {
+			log.Printf("Invalid %s entry %q, skipping it", key, item)
+			continue
+		}
+		flags[name] = on
+	}
+	return flags
+}
+
+// envIntRange parses an inclusive range such as "0-100" or "-10-10",
+// returning nil when unset or invalid.
+func envIntRange(key string) *[2]int {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return nil
+	}
+	if i := strings.Index(v[1:], "-"); i >= 0 { // Skip a leading minus sign
+		from, errFrom := strconv.Atoi(strings.TrimSpace(v[:i+1]))
+		to, errTo := strconv.Atoi(strings.TrimSpace(v[i+2:]))
+		if errFrom == nil && errTo == nil && from <= to {
+			return &[2]int{from, to}
+		}
+	}
+	log.Printf("Invalid %s=%q, expected a range such as 0-100; precomputing nothing", key, v)
+	return nil
+}
+
+// envDuration parses a duration environment variable (e.g. "15s") or returns a default.
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Invalid %s=%q, using default %s", key, v, def)
+		return def
+	}
+	return d
+}
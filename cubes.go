@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// twoCubesResult is the body of GET /api/sum-of-two-cubes.
+type twoCubesResult struct {
+	Number          int      `json:"number"`
+	Representations [][2]int `json:"representations"` // Every [a, b] with 1 <= a <= b and a³ + b³ = number
+	Count           int      `json:"count"`
+	IsTaxicab       bool     `json:"is_taxicab"` // More than one representation, like 1729
+}
+
+// sumOfTwoCubes lists every way to write the number as a sum of two positive
+// cubes.
+func sumOfTwoCubes(c *gin.Context) {
+	number, ok := numberQuery(c)
+	if !ok {
+		return
+	}
+	switch {
+	case number < 0:
+		respondError(c, http.StatusBadRequest, c.Query("number"), "number must not be negative")
+		return
+	case number > cfg.TwoCubesMaxNumber:
+		respondError(c, http.StatusBadRequest, c.Query("number"), fmt.Sprintf("number must be at most %d", cfg.TwoCubesMaxNumber))
+		return
+	}
+
+	pairs := twoCubes(number)
+	render(c, http.StatusOK, twoCubesResult{
+		Number:          number,
+		Representations: pairs,
+		Count:           len(pairs),
+		IsTaxicab:       len(pairs) > 1,
+	})
+}
+
+// twoCubes returns every [a, b] with 1 <= a <= b and a³ + b³ = n in canonical
+// order, by ascending a. a can be at most ∛(n/2), and b is then fixed by a.
+func twoCubes(n int) [][2]int {
+	pairs := [][2]int{}
+	for a, limit := 1, iroot(n/2, 3); a <= limit; a++ {
+		rest := n - a*a*a
+		if b := iroot(rest, 3); b*b*b == rest {
+			pairs = append(pairs, [2]int{a, b})
+		}
+	}
+	return pairs
+}
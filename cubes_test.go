@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestSumOfTwoCubes(t *testing.T) {
+	tests := []struct {
+		n    int
+		want [][2]int
+	}{
+		{1729, [][2]int{{1, 12}, {9, 10}}},
+		{4104, [][2]int{{2, 16}, {9, 15}}},
+		{9, [][2]int{{1, 2}}},
+		{10, [][2]int{}},
+	}
+	for _, tt := range tests {
+		w := get(t, "/api/sum-of-two-cubes?number="+strconv.Itoa(tt.n))
+		var body twoCubesResult
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("%d: %d %s", tt.n, w.Code, w.Body)
+		}
+		if !slices.Equal(body.Representations, tt.want) || body.IsTaxicab != (len(tt.want) > 1) {
+			t.Errorf("%d: representations %v, is_taxicab %v, want %v", tt.n, body.Representations, body.IsTaxicab, tt.want)
+		}
+	}
+}
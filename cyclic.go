@@ -0,0 +1,93 @@
+package main
+
+import (
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cyclicMaxDigits caps the input length for /api/cyclic.
+const cyclicMaxDigits = 100
+
+// cyclicProduct is the result of multiplying the candidate by one multiplier.
+type cyclicProduct struct {
+	Multiplier int    `json:"multiplier"`
+	Product    string `json:"product"` // Zero-padded to the candidate's length
+	IsRotation bool   `json:"is_rotation"`
+}
+
+// cyclicResult is the body of GET /api/cyclic.
+type cyclicResult struct {
+	Number          string          `json:"number"`
+	Digits          int             `json:"digits"`
+	IsCyclic        bool            `json:"is_cyclic"`
+	Products        []cyclicProduct `json:"products"`
+	LeadingZeroForm *string         `json:"leading_zero_form"` // Set when only the zero-prefixed form is cyclic
+}
+
+// cyclicNumber reports whether an n-digit number is cyclic, i.e. multiplying
+// it by 1..n only ever rotates its digits (142857 × 3 = 428571). The number
+// is taken as a digit string so leading zeros count: 0588235294117647 is
+// cyclic, while 588235294117647 on its own is not.
+func cyclicNumber(c *gin.Context) {
+	digits := strings.TrimSpace(c.Query("number"))
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		respondError(c, http.StatusBadRequest, digits, "number must be a string of decimal digits")
+		return
+	}
+	if len(digits) > cyclicMaxDigits {
+		respondError(c, http.StatusBadRequest, truncateEcho(digits, cyclicMaxDigits), "number has too many digits")
+		return
+	}
+
+	recordInput(c, "number", digits)
+	result := checkCyclic(digits)
+
+	// A cyclic number may need a leading zero the client dropped, e.g. 1/17
+	if !result.IsCyclic && len(digits) < cyclicMaxDigits {
+		if padded := "0" + digits; checkCyclic(padded).IsCyclic {
+			result.LeadingZeroForm = &padded
+		}
+	}
+
+	render(c, http.StatusOK, result)
+}
+
+// checkCyclic multiplies digits by 1..len(digits) and checks each product is
+// a rotation. Single digits and all-zero strings are never cyclic.
+func checkCyclic(digits string) cyclicResult {
+	n := len(digits)
+	result := cyclicResult{Number: digits, Digits: n, IsCyclic: n > 1 && strings.Trim(digits, "0") != ""}
+
+	value, _ := new(big.Int).SetString(digits, 10)
+	rotations := digitRotations(digits)
+	for k := 1; k <= n; k++ {
+		product := new(big.Int).Mul(value, big.NewInt(int64(k))).String()
+		if len(product) < n {
+			product = strings.Repeat("0", n-len(product)) + product
+		}
+		isRotation := slices.Contains(rotations, product)
+		result.IsCyclic = result.IsCyclic && isRotation
+		result.Products = append(result.Products, cyclicProduct{Multiplier: k, Product: product, IsRotation: isRotation})
+	}
+	return result
+}
+
+// digitRotations lists the distinct rotations of a digit string, starting
+// with the string itself: "197" gives 197, 971 and 719, and "1313" just 1313
+// and 3131. Leading zeros are kept.
+func digitRotations(digits string) []string {
+	doubled := digits + digits // Every rotation is a substring of this
+	rotations := []string{digits}
+	for i := 1; i < len(digits); i++ {
+		rotation := doubled[i : i+len(digits)]
+		if rotation == digits {
+			break // Periodic: the rest repeat what came before
+		}
+		rotations = append(rotations, rotation)
+	}
+	return rotations
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dateResponse is the classification of a date's numeric encoding.
+type dateResponse struct {
+	Date       string `json:"date"`
+	Encoding   string `json:"encoding"`
+	DayOfYear  int    `json:"day_of_year"`
+	IsLeapYear bool   `json:"is_leap_year"`
+	DateFact   string `json:"date_fact"`
+	Classification
+}
+
+// dateEncodings turn a date into the number that gets classified.
+var dateEncodings = map[string]func(time.Time) int{
+	"day_of_year": func(t time.Time) int { return t.YearDay() },                                   // 2024-02-29 -> 60
+	"yyyymmdd":    func(t time.Time) int { return t.Year()*10000 + int(t.Month())*100 + t.Day() }, // 2024-02-29 -> 20240229
+}
+
+// classifyDate classifies a date as a number (its day of the year by
+// default) and adds a fact about that calendar day.
+func classifyDate(c *gin.Context) {
+	raw := strings.TrimSpace(c.Query("date"))
+	date, err := time.Parse(time.DateOnly, raw) // Rejects 2023-02-29 and 2024-13-01
+	if err != nil {
+		respondError(c, http.StatusBadRequest, truncateEcho(raw, 32), "date must be a valid calendar date in YYYY-MM-DD form")
+		return
+	}
+	encoding := strings.ToLower(c.DefaultQuery("encoding", "day_of_year"))
+	encode, ok := dateEncodings[encoding]
+	if !ok {
+		respondError(c, http.StatusBadRequest, encoding, "encoding must be day_of_year or yyyymmdd")
+		return
+	}
+
+	recordInput(c, "date", date.Format(time.DateOnly))
+	recordInput(c, "encoding", encoding)
+
+	// While overloaded, only cheap fields and no date fact fetch
+	mask := degradeMask(c, nil, "date", "encoding", "day_of_year", "is_leap_year")
+	result, cached := classifyCached(encode(date), classifyOptions{Debug: boolQuery(c, "debug"), Fields: mask})
+	setCacheHeader(c, cached)
+	response := dateResponse{
+		Date:           date.Format(time.DateOnly),
+		Encoding:       encoding,
+		DayOfYear:      date.YearDay(),
+		IsLeapYear:     isLeapYear(date.Year()),
+		Classification: result,
+	}
+	if mask.wants("date_fact") {
+		response.DateFact = getDateFact(c.Request.Context(), date.Month(), date.Day())
+	}
+	if mask != nil {
+		renderMasked(c, http.StatusOK, response, mask)
+		return
+	}
+	stats.record(result)
+	renderEnabled(c, http.StatusOK, response)
+}
+
+// isLeapYear applies the Gregorian rule.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stopwatch records how long each classification step takes. A nil
+// stopwatch runs the steps without timing them, so normal requests pay nothing.
+type stopwatch struct {
+	start time.Time
+	steps map[string]float64
+}
+
+// newStopwatch starts a stopwatch for a debug request.
+func newStopwatch() *stopwatch {
+	return &stopwatch{start: time.Now(), steps: map[string]float64{}}
+}
+
+// time runs fn and, if the stopwatch is active, records its duration under step.
+func (s *stopwatch) time(step string, fn func()) {
+	if s == nil {
+		fn()
+		return
+	}
+	start := time.Now()
+	fn()
+	s.steps[step] += milliseconds(time.Since(start))
+}
+
+// result returns the recorded timings plus the total, or nil when inactive.
+func (s *stopwatch) result() map[string]float64 {
+	if s == nil {
+		return nil
+	}
+	s.steps["total"] = milliseconds(time.Since(s.start))
+	return s.steps
+}
+
+// milliseconds converts a duration to fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// debugBufferLimit is the most of a ?debug=true body held back for the
+// headers; larger bodies stream, like flushed ones.
+const debugBufferLimit = 1 << 20
+
+// debugHeaders adds X-Uncompressed-Length (the body size as the handler wrote
+// it) and X-Response-Time-Ms to ?debug=true responses. The body is held back
+// until the handler returns, so both headers can still go out ahead of it.
+// A handler that flushes (the /api/scan event stream) or writes more than
+// debugBufferLimit (a job export) streams instead, and both values arrive
+// as trailers.
+func debugHeaders() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if debug, _ := strconv.ParseBool(c.Query("debug")); !debug {
+			c.Next()
+			return
+		}
+		start := time.Now()
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+
+		length := strconv.Itoa(w.written)
+		elapsed := strconv.FormatFloat(milliseconds(time.Since(start)), 'f', 3, 64)
+		if w.streaming {
+			w.Header().Set(http.TrailerPrefix+"X-Uncompressed-Length", length)
+			w.Header().Set(http.TrailerPrefix+"X-Response-Time-Ms", elapsed)
+			return
+		}
+		w.Header().Set("X-Uncompressed-Length", length)
+		w.Header().Set("X-Response-Time-Ms", elapsed)
+		if w.body.Len() > 0 { // Nothing to write after a WebSocket upgrade, or for a 204
+			w.ResponseWriter.Write(w.body.Bytes())
+		}
+	}
+}
+
+// bufferedWriter holds a response body back from the underlying writer
+// until it is flushed or grows past debugBufferLimit, counting every byte.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	streaming bool // Writes now go straight through
+	written   int  // Body bytes so far, held back or not
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	w.written += len(b)
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	w.body.Write(b)
+	if w.body.Len() > debugBufferLimit {
+		if err := w.stream(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) { return w.Write([]byte(s)) }
+
+// Flush sends what is held back and everything after it straight through.
+func (w *bufferedWriter) Flush() {
+	if !w.streaming {
+		w.stream()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// stream writes out the held-back body and stops holding any more.
+func (w *bufferedWriter) stream() error {
+	w.streaming = true
+	_, err := w.ResponseWriter.Write(w.body.Bytes())
+	w.body = bytes.Buffer{}
+	return err
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDebugHeaders(t *testing.T) {
+	w := get(t, "/api/classify-number?number=7&debug=true")
+	if got := w.Header().Get("X-Uncompressed-Length"); got != strconv.Itoa(w.Body.Len()) {
+		t.Errorf("X-Uncompressed-Length = %q, body is %d bytes", got, w.Body.Len())
+	}
+	if w.Header().Get("X-Response-Time-Ms") == "" {
+		t.Error("X-Response-Time-Ms missing")
+	}
+}
+
+func TestDebugHeadersStreamAsTrailers(t *testing.T) {
+	w := get(t, "/api/scan?property=prime&from=10&limit=2&debug=true")
+	if !w.Flushed {
+		t.Fatal("scan events were not flushed as they were found")
+	}
+	trailer := w.Result().Trailer
+	if got := trailer.Get("X-Uncompressed-Length"); got != strconv.Itoa(w.Body.Len()) {
+		t.Errorf("X-Uncompressed-Length trailer = %q, body is %d bytes", got, w.Body.Len())
+	}
+	if trailer.Get("X-Response-Time-Ms") == "" {
+		t.Error("X-Response-Time-Ms trailer missing")
+	}
+}
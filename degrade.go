@@ -0,0 +1,92 @@
+package main
+
+import (
+	"log"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// inFlightRequests is the number of /api requests currently being served.
+var inFlightRequests atomic.Int64
+
+// degradedKey marks a request that arrived above DEGRADE_THRESHOLD.
+const degradedKey = "degraded"
+
+// defaultDegradedFields are served while degraded when DEGRADED_FIELDS is
+// unset: parity (in properties), sign and digit sum, none of which needs
+// factoring, divisors or a fun-fact fetch.
+var defaultDegradedFields = []string{"number", "properties", "sign", "digit_sum"}
+
+// degradedMask is the lite classification served above the threshold.
+var degradedMask = loadDegradedMask(cfg.DegradedFields)
+
+// loadDegradedMask parses DEGRADED_FIELDS. An unknown field is fatal, like
+// ENABLED_PROPERTIES, so a typo can't leave degraded responses empty.
+func loadDegradedMask(fields []string) fieldMask {
+	if len(fields) == 0 {
+		fields = defaultDegradedFields
+	}
+	mask := parseFieldMask(strings.Join(fields, ","))
+	if path, valid, ok := checkFieldMask(mask, reflect.TypeOf(Classification{}), ""); !ok {
+		log.Fatalf("Unknown field %q in DEGRADED_FIELDS; valid: %s", path, strings.Join(valid, ", "))
+	}
+	return mask
+}
+
+// shedLoad counts in-flight requests and marks those that push the count
+// above DEGRADE_THRESHOLD, so classification handlers serve them the lite
+// field set instead of doing the expensive work. A threshold of 0 turns this
+// off.
+func shedLoad() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		n := inFlightRequests.Add(1)
+		defer inFlightRequests.Add(-1)
+		if cfg.DegradeThreshold > 0 && n > int64(cfg.DegradeThreshold) {
+			c.Set(degradedKey, true)
+		}
+		c.Next()
+	}
+}
+
+// degradeMask narrows mask to the lite field set when the request is
+// degraded, setting X-Degraded so clients know fields were left out. keep
+// names cheap fields of a wrapping response, such as /api/random's seed,
+// that survive the narrowing.
+func degradeMask(c *gin.Context, mask fieldMask, keep ...string) fieldMask {
+	if !c.GetBool(degradedKey) {
+		return mask
+	}
+	c.Header("X-Degraded", "true")
+	return liteMask(mask, keep...)
+}
+
+// overloaded reports whether more than DEGRADE_THRESHOLD /api requests are in
+// flight, for WebSocket messages and gRPC calls, which shedLoad never sees.
+func overloaded() bool {
+	return cfg.DegradeThreshold > 0 && inFlightRequests.Load() > int64(cfg.DegradeThreshold)
+}
+
+// liteMask narrows mask to the lite field set plus the keep fields it
+// selects. "number" is always kept so a narrowed response is never empty.
+func liteMask(mask fieldMask, keep ...string) fieldMask {
+	degradedRequests.Inc()
+	lite := fieldMask{"number": nil}
+	for _, name := range keep {
+		if mask.wants(name) {
+			lite[name] = mask[name]
+		}
+	}
+	for name, sub := range degradedMask {
+		if !mask.wants(name) {
+			continue
+		}
+		if sub == nil && mask != nil {
+			sub = mask[name] // The whole field is lite, so the client's subfields stand
+		}
+		lite[name] = sub
+	}
+	return lite
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// overload pushes the in-flight count above DEGRADE_THRESHOLD, even without
+// the request under test, until the returned function is called.
+func overload() func() {
+	threshold := cfg.DegradeThreshold
+	cfg.DegradeThreshold = 1
+	inFlightRequests.Add(2)
+	return func() {
+		inFlightRequests.Add(-2)
+		cfg.DegradeThreshold = threshold
+	}
+}
+
+func TestDegradedEndpoints(t *testing.T) {
+	defer overload()()
+
+	tests := []struct {
+		target string
+		keep   string // A cheap field of the endpoint's own
+	}{
+		{"/api/classify-number?number=28", "sign"},
+		{"/api/random?min=28&max=28", "seed"},
+		{"/api/classify-expr?expr=4*7", "expression"},
+		{"/api/classify-date?date=2024-01-28", "is_leap_year"},
+	}
+	for _, tt := range tests {
+		w := get(t, tt.target)
+		var body map[string]any
+		decode(t, w, &body)
+		if w.Header().Get("X-Degraded") != "true" {
+			t.Errorf("%s: no X-Degraded header", tt.target)
+		}
+		if _, ok := body[tt.keep]; !ok {
+			t.Errorf("%s: %s missing from %v", tt.target, tt.keep, body)
+		}
+		for _, name := range []string{"is_prime", "is_perfect", "fun_fact", "date_fact"} {
+			if _, ok := body[name]; ok {
+				t.Errorf("%s: %s served while degraded", tt.target, name)
+			}
+		}
+	}
+}
+
+func TestDegradedWebSocketMessage(t *testing.T) {
+	defer overload()()
+
+	raw, err := json.Marshal(wsClassify(context.Background(), "28"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["number"] != float64(28) {
+		t.Errorf("number = %v", body["number"])
+	}
+	if _, ok := body["is_perfect"]; ok {
+		t.Errorf("is_perfect served while degraded: %v", body)
+	}
+}
+
+func TestNotDegradedAtThreshold(t *testing.T) {
+	defer func(n int) { cfg.DegradeThreshold = n }(cfg.DegradeThreshold)
+	cfg.DegradeThreshold = 1
+
+	w := get(t, "/api/classify-number?number=28")
+	if w.Code != http.StatusOK || w.Header().Get("X-Degraded") != "" {
+		t.Errorf("status %d, X-Degraded %q for the only request in flight", w.Code, w.Header().Get("X-Degraded"))
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// digitalRootResult is the body of GET /api/digital-root.
+type digitalRootResult struct {
+	Number      int      `json:"number"`
+	Base        int      `json:"base"`
+	DigitalRoot uint64   `json:"digital_root"`
+	Steps       []uint64 `json:"steps"` // Digit sums taken on the way to the root
+}
+
+// digitalRoot returns the repeated digit sum of a number in a given base
+// (default 10). Negative numbers use their magnitude.
+func digitalRoot(c *gin.Context) {
+	number, ok := numberQuery(c)
+	if !ok {
+		return
+	}
+	base, ok := intQuery(c, "base", 10)
+	if !ok {
+		return
+	}
+	if base < 2 {
+		respondError(c, http.StatusBadRequest, c.Query("base"), "base must be at least 2")
+		return
+	}
+
+	steps := []uint64{}
+	for n := magnitude(number); n >= uint64(base); {
+		n = digitSumInBase(n, uint64(base))
+		steps = append(steps, n)
+	}
+
+	render(c, http.StatusOK, digitalRootResult{
+		Number:      number,
+		Base:        base,
+		DigitalRoot: digitalRootInBase(magnitude(number), uint64(base)),
+		Steps:       steps,
+	})
+}
+
+// digitalRootInBase uses the closed form 1 + (n-1) mod (base-1).
+func digitalRootInBase(n, base uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	return 1 + (n-1)%(base-1)
+}
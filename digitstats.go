@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/adidazbot/num_class_api/numclass"
+)
+
+// digitStats is defined in the numclass package.
+type digitStats = numclass.DigitStats
+
+// describeDigits summarizes the decimal digits of |n|. Min, max, mean,
+// median and parity come from the digit frequencies; the orderings compare
+// neighbouring digits left to right.
+func describeDigits(n int) *digitStats {
+	counts := digitCounts(magnitude(n), 10)
+	digits := strconv.FormatUint(magnitude(n), 10)
+	stats := &digitStats{Count: len(digits), Min: -1, StrictlyIncreasing: true, StrictlyDecreasing: true, NonDecreasing: true}
+
+	sum, seen := 0, 0
+	lowMid, highMid := (len(digits)-1)/2, len(digits)/2 // Positions of the middle digits in sorted order
+	for d, count := range counts {
+		if count == 0 {
+			continue
+		}
+		if stats.Min < 0 {
+			stats.Min = d
+		}
+		stats.Max = d
+		sum += d * count
+		if d%2 == 0 {
+			stats.EvenCount += count
+		} else {
+			stats.OddCount += count
+		}
+		if seen <= lowMid && lowMid < seen+count {
+			stats.Median += float64(d) / 2
+		}
+		if seen <= highMid && highMid < seen+count {
+			stats.Median += float64(d) / 2
+		}
+		seen += count
+	}
+	stats.Mean = float64(sum) / float64(len(digits))
+
+	for i := 1; i < len(digits); i++ {
+		prev, cur := digits[i-1], digits[i]
+		stats.StrictlyIncreasing = stats.StrictlyIncreasing && cur > prev
+		stats.StrictlyDecreasing = stats.StrictlyDecreasing && cur < prev
+		stats.NonDecreasing = stats.NonDecreasing && cur >= prev
+	}
+	return stats
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDescribeDigits(t *testing.T) {
+	tests := []struct {
+		n    int
+		want digitStats
+	}{
+		{123456789, digitStats{Count: 9, Min: 1, Max: 9, Mean: 5, Median: 5, EvenCount: 4, OddCount: 5, StrictlyIncreasing: true, NonDecreasing: true}},
+		{112233, digitStats{Count: 6, Min: 1, Max: 3, Mean: 2, Median: 2, EvenCount: 2, OddCount: 4, NonDecreasing: true}},
+		{9630, digitStats{Count: 4, Min: 0, Max: 9, Mean: 4.5, Median: 4.5, EvenCount: 2, OddCount: 2, StrictlyDecreasing: true}},
+		{-7, digitStats{Count: 1, Min: 7, Max: 7, Mean: 7, Median: 7, OddCount: 1, StrictlyIncreasing: true, StrictlyDecreasing: true, NonDecreasing: true}},
+		{0, digitStats{Count: 1, EvenCount: 1, StrictlyIncreasing: true, StrictlyDecreasing: true, NonDecreasing: true}},
+	}
+	for _, tt := range tests {
+		if got := describeDigits(tt.n); *got != tt.want {
+			t.Errorf("describeDigits(%d) = %+v, want %+v", tt.n, *got, tt.want)
+		}
+	}
+}
+
+func TestDigitStatsQuery(t *testing.T) {
+	var body struct {
+		DigitStats *digitStats `json:"digit_stats"`
+	}
+	if err := json.Unmarshal(get(t, "/api/classify-number?number=9630&digit_stats=true").Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.DigitStats == nil || *body.DigitStats != *describeDigits(9630) {
+		t.Errorf("digit_stats = %+v", body.DigitStats)
+	}
+	body.DigitStats = nil
+	if err := json.Unmarshal(get(t, "/api/classify-number?number=9630").Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.DigitStats != nil {
+		t.Errorf("digit_stats without ?digit_stats=: %+v", body.DigitStats)
+	}
+}
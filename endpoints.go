@@ -0,0 +1,77 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// knownEndpoints and enabledEndpoints record, by name, every API endpoint
+// and those ENDPOINT_FLAGS left mounted. An endpoint's name is the first
+// segment of its path, so "jobs" covers /api/jobs and everything under it.
+var knownEndpoints, enabledEndpoints = map[string]bool{}, map[string]bool{}
+
+// flaggedRoutes mounts API routes the way gin.RouterGroup does, skipping
+// those whose endpoint ENDPOINT_FLAGS turns off so they answer 404.
+type flaggedRoutes struct {
+	group *gin.RouterGroup
+}
+
+// GET mounts a GET route unless its endpoint is disabled.
+func (r flaggedRoutes) GET(path string, handlers ...gin.HandlerFunc) {
+	r.handle(http.MethodGet, path, handlers)
+}
+
+// POST mounts a POST route unless its endpoint is disabled.
+func (r flaggedRoutes) POST(path string, handlers ...gin.HandlerFunc) {
+	r.handle(http.MethodPost, path, handlers)
+}
+
+func (r flaggedRoutes) handle(method, path string, handlers []gin.HandlerFunc) {
+	name, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	knownEndpoints[name] = true
+	if !endpointEnabled(name) {
+		return
+	}
+	enabledEndpoints[name] = true
+	r.group.Handle(method, path, handlers...)
+}
+
+// endpointEnabled looks name up in ENDPOINT_FLAGS, falling back to its "*"
+// entry and then to on.
+func endpointEnabled(name string) bool {
+	if on, ok := cfg.EndpointFlags[name]; ok {
+		return on
+	}
+	if on, ok := cfg.EndpointFlags["*"]; ok {
+		return on
+	}
+	return true
+}
+
+// checkEndpointFlags stops the server when ENDPOINT_FLAGS names an endpoint
+// that doesn't exist, like ENABLED_PROPERTIES, so a typo can't leave an
+// endpoint on that was meant to be off. It runs once the routes are mounted.
+func checkEndpointFlags(flags map[string]bool) {
+	for name := range flags {
+		if name != "*" && !knownEndpoints[name] {
+			log.Fatalf("Unknown endpoint %q in ENDPOINT_FLAGS; valid: %s", name, strings.Join(endpointNames(knownEndpoints), ", "))
+		}
+	}
+	if len(flags) > 0 {
+		log.Printf("Endpoint flags applied: %d of %d endpoints enabled", len(enabledEndpoints), len(knownEndpoints))
+	}
+}
+
+// endpointNames returns the names in set, sorted.
+func endpointNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
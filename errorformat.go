@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Error body shapes, chosen by ERROR_FORMAT or the X-Error-Format header.
+const (
+	errorFormatLegacy     = "legacy"     // {"error": true, "message": ..., <context>...}
+	errorFormatStructured = "structured" // {"error": {"code", "message", "details"}}
+)
+
+// structuredError is the "error" object of the structured shape.
+type structuredError struct {
+	Code    string `json:"code"` // Snake-cased HTTP status text, e.g. "bad_request"
+	Message string `json:"message"`
+	Details gin.H  `json:"details"` // The context the legacy shape puts beside "message"
+}
+
+// errorFormat picks the error shape for this request. An unrecognized header
+// value falls back to the configured default.
+func errorFormat(c *gin.Context) string {
+	switch format := strings.ToLower(strings.TrimSpace(c.GetHeader("X-Error-Format"))); format {
+	case errorFormatLegacy, errorFormatStructured:
+		return format
+	}
+	return cfg.ErrorFormat
+}
+
+// errorBody converts a legacy error body to the shape the client asked for.
+// Anything that isn't an error body is returned unchanged.
+func errorBody(c *gin.Context, status int, v interface{}) interface{} {
+	h, ok := v.(gin.H)
+	if !ok || h["error"] != true {
+		return v
+	}
+	c.Writer.Header().Add("Vary", "X-Error-Format")
+	if errorFormat(c) != errorFormatStructured {
+		return v
+	}
+	details := gin.H{}
+	for key, value := range h {
+		if key != "error" && key != "message" {
+			details[key] = value
+		}
+	}
+	message, _ := h["message"].(string)
+	code := strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+	return gin.H{"error": structuredError{Code: code, Message: message, Details: details}}
+}
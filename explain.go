@@ -0,0 +1,417 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// explainMaxTerms caps how many divisors an explanation spells out.
+const explainMaxTerms = 20
+
+// explain describes why each property of a classification holds or not,
+// keyed by registry property name. It only runs with ?explain=true, and
+// rechecks properties a field mask may have skipped. factorsOf supplies the
+// factorization classify already computed.
+func explain(r Classification, factorsOf func() []primeFactor) map[string]string {
+	n := r.Number
+	var factors []primeFactor
+	if n >= 2 {
+		factors = factorsOf()
+	}
+
+	out := map[string]string{
+		"prime":          explainPrime(n, factors),
+		"perfect":        explainPerfect(n, factors),
+		"practical":      explainPractical(n, practical(n, factorsOf)),
+		"armstrong":      explainArmstrong(n),
+		"even":           fmt.Sprintf("%d mod 2 = %d", n, magnitude(n)%2),
+		"odd":            fmt.Sprintf("%d mod 2 = %d", n, magnitude(n)%2),
+		"carmichael":     explainCarmichael(n, factors, carmichael(n, factorsOf)),
+		"sphenic":        explainSphenic(n, factors, sphenic(n, factorsOf)),
+		"achilles":       explainAchilles(n, factors),
+		"self":           explainSelf(n),
+		"palindrome":     explainPalindrome(n),
+		"undulating":     explainUndulating(n),
+		"circular_prime": explainCircularPrime(n),
+		"primorial":      explainPrimorial(n),
+		"duffinian":      explainDuffinian(n, factors),
+		"hoax":           explainHoax(n, factors),
+		"keith":          explainKeith(n),
+		"disarium":       explainDisarium(n),
+	}
+	out["pandigital"], out["zeroless_pandigital"] = explainPandigital(n)
+
+	if isPowerOfTwo(n) {
+		out["power_of_two"] = fmt.Sprintf("%d = 2^%d", n, bits.TrailingZeros64(uint64(n)))
+	} else {
+		out["power_of_two"] = fmt.Sprintf("%d is not 2 raised to any power", n)
+	}
+	if k, ok := triangularIndex(n); ok {
+		out["triangular"] = fmt.Sprintf("%d = %d × %d / 2", n, k, k+1)
+		if k >= 2 {
+			out["triangular"] += fmt.Sprintf(", the sum 1 + … + %d", k)
+		}
+	} else {
+		out["triangular"] = fmt.Sprintf("%d is not k(k+1)/2 for any whole k", n)
+	}
+	if k, ok := squareIndex(n); ok {
+		out["square"] = fmt.Sprintf("%d = %d²", n, k)
+	} else {
+		out["square"] = fmt.Sprintf("%d is not the square of a whole number", n)
+	}
+	ones := bits.OnesCount64(magnitude(n))
+	parity := "even"
+	if ones%2 == 1 {
+		parity = "odd"
+	}
+	unit := "one bits"
+	if ones == 1 {
+		unit = "one bit"
+	}
+	binary := fmt.Sprintf("%d in binary is %s, which has %d %s (%s)", n, strconv.FormatUint(magnitude(n), 2), ones, unit, parity)
+	out["evil"], out["odious"] = binary, binary
+	out["binary_palindrome"] = explainBinaryPalindrome(n)
+	out["highly_composite"] = explainHighlyComposite(n, factors)
+	for name := range disabledProperties {
+		delete(out, name)
+	}
+	return out
+}
+
+func explainPrime(n int, factors []primeFactor) string {
+	switch {
+	case n < 0:
+		verdict := "is not prime"
+		if m := magnitude(n); m <= math.MaxInt && isPrime(int(m)) {
+			verdict = "is prime"
+		}
+		return fmt.Sprintf("primality is undefined for negative numbers; the magnitude %d %s", magnitude(n), verdict)
+	case n < 2:
+		return fmt.Sprintf("%d is below 2, and primes start at 2", n)
+	case len(factors) == 1 && factors[0].Exponent == 1:
+		return fmt.Sprintf("%d has no divisors other than 1 and itself", n)
+	}
+	p := factors[0].Prime
+	return fmt.Sprintf("%d is divisible by %d (%d = %d × %d)", n, p, n, p, n/p)
+}
+
+func explainPerfect(n int, factors []primeFactor) string {
+	if n < 1 {
+		return fmt.Sprintf("%d is not positive, and perfect numbers are", n)
+	}
+	if n == 1 {
+		return "1 has no proper divisors, so their sum is 0"
+	}
+	divisors := divisorsFrom(factors, cfg.MaxDivisors)
+	terms := joinTerms(divisors[:len(divisors)-1], " + ")
+	if count := divisorCount(factors); len(divisors) < count {
+		terms = fmt.Sprintf("%s + … (%d in all)", joinTerms(divisors, " + "), count-1) // Cut at MAX_DIVISORS, like verbose
+	}
+	sum := bigSigma(factors)
+	sum.Sub(sum, big.NewInt(int64(n)))
+	relation := "="
+	if !sum.IsInt64() || int(sum.Int64()) != n {
+		relation = "≠"
+	}
+	return fmt.Sprintf("proper divisors %s sum to %s %s %d", terms, sum, relation, n)
+}
+
+func explainPractical(n int, practical bool) string {
+	switch {
+	case practical:
+		return fmt.Sprintf("every number from 1 to %d is a sum of distinct divisors of %d", max(n-1, 1), n)
+	case n < 1:
+		return fmt.Sprintf("%d is not positive, and practical numbers are", n)
+	case n%2 != 0:
+		return fmt.Sprintf("%d is odd, and the only odd practical number is 1", n)
+	}
+	return fmt.Sprintf("some number below %d is not a sum of distinct divisors of %d", n, n)
+}
+
+func explainArmstrong(n int) string {
+	digits := strconv.FormatUint(magnitude(n), 10) // Digits of the magnitude, as for every digit-based property
+	terms := make([]string, len(digits))
+	for i, d := range digits {
+		terms[i] = fmt.Sprintf("%c^%d", d, len(digits))
+	}
+	relation := "≠"
+	if isArmstrong(n) {
+		relation = "="
+	}
+	return fmt.Sprintf("%s %s %s", strings.Join(terms, " + "), relation, digits)
+}
+
+func explainDisarium(n int) string {
+	digits := strconv.FormatUint(magnitude(n), 10)
+	terms := make([]string, len(digits))
+	for i, d := range digits {
+		terms[i] = fmt.Sprintf("%c^%d", d, i+1)
+	}
+	relation := "≠"
+	if isDisarium(n) {
+		relation = "="
+	}
+	return fmt.Sprintf("%s = %d %s %s", strings.Join(terms, " + "), disariumSum(digits), relation, digits)
+}
+
+func explainBinaryPalindrome(n int) string {
+	binary := strconv.FormatUint(magnitude(n), 2)
+	reversed := []byte(binary)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	if isBinaryPalindrome(n) {
+		return fmt.Sprintf("%d in binary is %s, which reads the same backwards", n, binary)
+	}
+	return fmt.Sprintf("%d in binary is %s, which reversed is %s", n, binary, reversed)
+}
+
+// explainHighlyComposite compares n's divisor count with that of the largest
+// highly composite number below it, which has the most divisors of any
+// smaller number.
+func explainHighlyComposite(n int, factors []primeFactor) string {
+	if n < 1 {
+		return fmt.Sprintf("%d is below 1, the smallest highly composite number", n)
+	}
+	divisors := divisorCount(factors)
+	below, _ := nearestMember(highlyCompositeNumbers, n)
+	switch {
+	case below == nil:
+		return "1 has 1 divisor, and there is no smaller positive number"
+	case isHighlyComposite(n):
+		return fmt.Sprintf("%d has %d divisors, more than the %d of %d or any other smaller number", n, divisors, divisorCount(factorize(*below)), *below)
+	}
+	return fmt.Sprintf("%d has %d divisors, while the smaller %d already has %d", n, divisors, *below, divisorCount(factorize(*below)))
+}
+
+func explainCarmichael(n int, factors []primeFactor, carmichael bool) string {
+	if carmichael {
+		steps := make([]int, len(factors))
+		for i, f := range factors {
+			steps[i] = f.Prime - 1
+		}
+		return fmt.Sprintf("%d = %s is squarefree and %d is divisible by each of %s", n, formatFactors(factors), n-1, joinTerms(steps, ", "))
+	}
+	if n < 2 {
+		return fmt.Sprintf("%d is below 2, so it is not composite", n)
+	}
+	if len(factors) == 1 && factors[0].Exponent == 1 {
+		return fmt.Sprintf("%d is prime, and Carmichael numbers are composite", n)
+	}
+	return fmt.Sprintf("%d = %s fails Korselt's criterion (squarefree, with p-1 dividing %d for each prime p)", n, formatFactors(factors), n-1)
+}
+
+func explainSphenic(n int, factors []primeFactor, sphenic bool) string {
+	if sphenic {
+		return fmt.Sprintf("%d = %s, three distinct primes", n, formatFactors(factors))
+	}
+	if n < 2 {
+		return fmt.Sprintf("%d is below 2, so it has no prime factorization", n)
+	}
+	if len(factors) == 1 && factors[0].Exponent == 1 {
+		return fmt.Sprintf("%d is prime, not a product of three primes", n)
+	}
+	return fmt.Sprintf("%d = %s, not exactly three distinct primes", n, formatFactors(factors))
+}
+
+func explainAchilles(n int, factors []primeFactor) string {
+	if n < 2 {
+		return fmt.Sprintf("%d is below 2, so it has no prime factorization", n)
+	}
+	for _, f := range factors {
+		if f.Exponent < 2 {
+			return fmt.Sprintf("%d = %s is not powerful: %d appears only once", n, formatFactors(factors), f.Prime)
+		}
+	}
+	if k := exponentGCD(factors); k >= 2 {
+		return fmt.Sprintf("%d = %s is powerful, but every exponent is a multiple of %d, so it is a perfect power (m^%d)", n, formatFactors(factors), k, k)
+	}
+	return fmt.Sprintf("%d = %s is powerful, and its exponents share no common factor, so it is not a perfect power", n, formatFactors(factors))
+}
+
+func explainSelf(n int) string {
+	if m, found := selfGenerator(n); found {
+		return fmt.Sprintf("%d = %d + %d, the digit sum of %d", n, m, digitSum(m), m)
+	}
+	if n < 1 {
+		return fmt.Sprintf("%d is not positive, and self numbers are", n)
+	}
+	return fmt.Sprintf("no m satisfies m + digit sum of m = %d", n)
+}
+
+// explainPandigital covers both the 0-9 and the zeroless 1-9 checks.
+func explainPandigital(n int) (string, string) {
+	var missing []int
+	for d, count := range digitCounts(magnitude(n), 10) {
+		if count == 0 {
+			missing = append(missing, d)
+		}
+	}
+	nonzero := missing
+	if len(missing) > 0 && missing[0] == 0 {
+		nonzero = missing[1:]
+	}
+	describe := func(missing []int, from int) string {
+		if len(missing) == 0 {
+			return fmt.Sprintf("%d uses every digit from %d to 9", n, from)
+		}
+		return fmt.Sprintf("%d never uses the digit(s) %s", n, joinTerms(missing, ", "))
+	}
+	return describe(missing, 0), describe(nonzero, 1)
+}
+
+func explainPalindrome(n int) string {
+	digits := strconv.FormatUint(magnitude(n), 10)
+	reversed := []byte(digits)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	if isPalindrome(n) {
+		return fmt.Sprintf("%s reads the same backwards", digits)
+	}
+	return fmt.Sprintf("%s reversed is %s", digits, reversed)
+}
+
+// explainUndulating names the alternating digits, or where the pattern breaks.
+func explainUndulating(n int) string {
+	digits := strconv.FormatUint(magnitude(n), 10)
+	switch {
+	case len(digits) < 3:
+		return fmt.Sprintf("%s has fewer than 3 digits, so it doesn't undulate", digits)
+	case digits[0] == digits[1]:
+		return fmt.Sprintf("%s starts with a repeated %c, not two distinct digits", digits, digits[0])
+	}
+	for i := 2; i < len(digits); i++ {
+		if digits[i] != digits[i-2] {
+			return fmt.Sprintf("%s breaks the %c-%c pattern at digit %d", digits, digits[0], digits[1], i+1)
+		}
+	}
+	return fmt.Sprintf("%s alternates between %c and %c", digits, digits[0], digits[1])
+}
+
+// explainCircularPrime names the first rotation that isn't prime.
+func explainCircularPrime(n int) string {
+	if n < 2 {
+		return fmt.Sprintf("%d is below 2, and primes start at 2", n)
+	}
+	rotations := digitRotations(strconv.Itoa(n))
+	for _, rotation := range rotations {
+		if !isPrimeDigits(rotation) {
+			v, _ := strconv.ParseUint(rotation, 10, 64)
+			return fmt.Sprintf("%s is a rotation of %d and is not prime", strconv.FormatUint(v, 10), n)
+		}
+	}
+	return fmt.Sprintf("every rotation of %d is prime: %s", n, strings.Join(rotations, ", "))
+}
+
+// explainPrimorial divides out 2, 3, 5, ... in turn and names the first prime
+// that is missing or repeated.
+func explainPrimorial(n int) string {
+	switch {
+	case n < 1:
+		return fmt.Sprintf("%d is below 1, the smallest primorial", n)
+	case n == 1:
+		return "1 is 0#, the empty product"
+	}
+	rest := n
+	for k, p := range firstPrimes(16) { // 47#, the largest that fits, uses 15
+		if rest%p != 0 {
+			return fmt.Sprintf("%d skips the prime %d", n, p)
+		}
+		rest /= p
+		switch {
+		case rest%p == 0:
+			return fmt.Sprintf("%d has the prime factor %d more than once", n, p)
+		case rest == 1 && k == 0:
+			return "2 = 2#, the first prime on its own"
+		case rest == 1:
+			return fmt.Sprintf("%d = %d#, the product of the first %d primes", n, p, k+1)
+		}
+	}
+	return fmt.Sprintf("%d is too large to be a primorial", n)
+}
+
+// explainDuffinian gives σ(n) and its gcd with n.
+func explainDuffinian(n int, factors []primeFactor) string {
+	switch {
+	case n < 4:
+		return fmt.Sprintf("%d is not composite, and Duffinian numbers are", n)
+	case len(factors) == 1 && factors[0].Exponent == 1:
+		return fmt.Sprintf("%d is prime, and Duffinian numbers are composite", n)
+	}
+	sigma := bigSigma(factors)
+	g := new(big.Int).GCD(nil, nil, big.NewInt(int64(n)), sigma)
+	if g.Cmp(big.NewInt(1)) == 0 {
+		return fmt.Sprintf("σ(%d) = %s, and gcd(%d, %s) = 1", n, sigma, n, sigma)
+	}
+	return fmt.Sprintf("σ(%d) = %s shares the factor %s with %d", n, sigma, g, n)
+}
+
+// explainHoax compares the digit sums of n and of its distinct prime factors.
+func explainHoax(n int, factors []primeFactor) string {
+	switch {
+	case n < 4:
+		return fmt.Sprintf("%d is not composite, and hoax numbers are", n)
+	case len(factors) == 1 && factors[0].Exponent == 1:
+		return fmt.Sprintf("%d is prime, and hoax numbers are composite", n)
+	}
+	primes := make([]string, len(factors))
+	for i, f := range factors {
+		primes[i] = strconv.Itoa(f.Prime)
+	}
+	sum, want := distinctFactorDigitSum(factors), digitSum(n)
+	verb := "equals"
+	if sum != want {
+		verb = "does not equal"
+	}
+	return fmt.Sprintf("%d = %s; the digit sums of %s add up to %d, which %s the digit sum %d", n, formatFactors(factors), strings.Join(primes, ", "), sum, verb, want)
+}
+
+// explainKeith lists n's Keith sequence up to where it meets or passes n.
+func explainKeith(n int) string {
+	if n < 10 {
+		return fmt.Sprintf("%d has fewer than 2 digits, and Keith numbers need at least 2", n)
+	}
+	terms, overflowed := keithTerms(n)
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = strconv.Itoa(t)
+	}
+	sequence := strings.Join(parts, ", ")
+	if overflowed {
+		return fmt.Sprintf("the sequence seeded by the digits of %d is %s, and the next term is beyond the int range, so it skips %d", n, sequence, n)
+	}
+	if last := terms[len(terms)-1]; last != n {
+		return fmt.Sprintf("the sequence seeded by the digits of %d is %s, which skips from %d to %d", n, sequence, terms[len(terms)-2], last)
+	}
+	return fmt.Sprintf("the sequence seeded by the digits of %d is %s, which reaches %d", n, sequence, n)
+}
+
+// formatFactors writes a factorization as "2^2 × 7".
+func formatFactors(factors []primeFactor) string {
+	parts := make([]string, len(factors))
+	for i, f := range factors {
+		parts[i] = strconv.Itoa(f.Prime)
+		if f.Exponent > 1 {
+			parts[i] += "^" + strconv.Itoa(f.Exponent)
+		}
+	}
+	return strings.Join(parts, " × ")
+}
+
+// joinTerms joins numbers with sep, eliding the middle of long lists.
+func joinTerms(terms []int, sep string) string {
+	parts := make([]string, 0, min(len(terms), explainMaxTerms+1))
+	for i, t := range terms {
+		if len(terms) > explainMaxTerms && i == explainMaxTerms-1 {
+			parts = append(parts, "…", strconv.Itoa(terms[len(terms)-1]))
+			break
+		}
+		parts = append(parts, strconv.Itoa(t))
+	}
+	return strings.Join(parts, sep)
+}
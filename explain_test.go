@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestExplainSkippedFields(t *testing.T) {
+	// The field mask skips the checks, so explain has to redo them
+	var resp struct {
+		Explanations map[string]string
+	}
+	decode(t, get(t, "/api/classify-number?number=36&explain=true&fields=explanations"), &resp)
+	want := map[string]string{
+		"power_of_two": "36 is not 2 raised to any power",
+		"triangular":   "36 = 8 × 9 / 2, the sum 1 + … + 8",
+		"square":       "36 = 6²",
+	}
+	for name, text := range want {
+		if got := resp.Explanations[name]; got != text {
+			t.Errorf("%s: %q, want %q", name, got, text)
+		}
+	}
+}
+
+func TestFormatFactors(t *testing.T) {
+	if got := formatFactors(factorize(28)); got != "2^2 × 7" {
+		t.Errorf("formatFactors(28) = %q", got)
+	}
+}
+
+func TestExplainKeith(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{14, "the sequence seeded by the digits of 14 is 1, 4, 5, 9, 14, which reaches 14"},
+		{15, "the sequence seeded by the digits of 15 is 1, 5, 6, 11, 17, which skips from 11 to 17"},
+	}
+	for _, tt := range tests {
+		if got := explainKeith(tt.n); got != tt.want {
+			t.Errorf("explainKeith(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
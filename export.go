@@ -0,0 +1,55 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportJob streams the results of a finished job as a gzip-compressed CSV,
+// one row per number. Rows are encoded one at a time straight into the gzip
+// writer, so memory use doesn't grow with the size of the job.
+func exportJob(c *gin.Context) {
+	if format := c.DefaultQuery("format", "csv"); format != "csv" {
+		render(c, http.StatusBadRequest, gin.H{"error": true, "message": "only format=csv can be exported", "valid_formats": []string{"csv"}})
+		return
+	}
+	j, ok := jobs.get(c.Param("id"))
+	if !ok {
+		render(c, http.StatusNotFound, gin.H{"error": true, "message": "job not found"})
+		return
+	}
+	if j.Status != jobDone {
+		render(c, http.StatusConflict, gin.H{"error": true, "message": "job has not finished yet", "status": j.Status})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Encoding", "gzip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="job-%s.csv"`, j.ID))
+	c.Status(http.StatusOK)
+
+	gz := gzip.NewWriter(c.Writer)
+	cw := csv.NewWriter(gz)
+	for i, result := range j.Results {
+		tree, err := toOrderedTree(result) // csvRow flattens JSON trees, not structs
+		if err != nil {
+			log.Printf("Job %s: failed to encode row %d: %v", j.ID, i, err)
+			break
+		}
+		header, row := csvRow(applyFieldMask(tree, enabledFields(reflect.TypeOf(result))))
+		if i == 0 {
+			cw.Write(header)
+		}
+		if err := cw.Write(row); err != nil {
+			return // Client went away; nothing more can be sent
+		}
+	}
+	cw.Flush()
+	gz.Close()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportJobCSV(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/jobs", strings.NewReader(`{"numbers": [28, 7]}`))
+	req.Header.Set("Content-Type", "application/json")
+	testRouter.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("POST /api/jobs = %d: %s", w.Code, w.Body)
+	}
+	var created struct{ ID string }
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+
+	var export *httptest.ResponseRecorder
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if export = get(t, "/api/jobs/"+created.ID+"/export"); export.Code != http.StatusConflict {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("job did not finish")
+		}
+	}
+	if export.Code != http.StatusOK {
+		t.Fatalf("export = %d: %s", export.Code, export.Body)
+	}
+
+	gz, err := gzip.NewReader(export.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(gz).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d CSV records, want a header and 2 rows", len(records))
+	}
+	header, row := records[0], records[1]
+	for _, want := range []string{"number", "is_prime", "is_perfect", "abundance", "properties"} {
+		if !slices.Contains(header, want) {
+			t.Errorf("header %q lacks %q", header, want)
+		}
+	}
+	cell := func(name string) string { return row[slices.Index(header, name)] }
+	if cell("number") != "28" || cell("is_perfect") != "true" || cell("is_prime") != "false" || cell("abundance") != "0" {
+		t.Errorf("row for 28 = %q", row)
+	}
+	if cell("properties") != "even" {
+		t.Errorf("properties = %q, want even", cell("properties"))
+	}
+}
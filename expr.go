@@ -0,0 +1,263 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Bounds that keep a hostile expression cheap to reject.
+const (
+	exprMaxLength = 256
+	exprMaxDepth  = 64   // Nested parentheses and unary signs
+	exprMaxBits   = 4096 // Largest intermediate value
+)
+
+// exprResponse is the classification of an evaluated expression.
+type exprResponse struct {
+	Expression string `json:"expression"`
+	Classification
+}
+
+// classifyExpression evaluates an integer expression and classifies the result.
+func classifyExpression(c *gin.Context) {
+	expr, ok := rawQueryParam(c, "expr")
+	if !ok || strings.TrimSpace(expr) == "" {
+		respondError(c, http.StatusBadRequest, expr, "expr is required")
+		return
+	}
+	if len(expr) > exprMaxLength {
+		respondError(c, http.StatusBadRequest, truncateEcho(expr, exprMaxLength), "expr is too long")
+		return
+	}
+
+	value, err := evalExpression(expr)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, expr, err.Error())
+		return
+	}
+	if !value.IsInt64() {
+		respondError(c, http.StatusBadRequest, expr, "result is outside the 64-bit integer range")
+		return
+	}
+
+	recordInput(c, "expr", expr)
+	mask := degradeMask(c, nil, "expression") // Only cheap fields while overloaded
+	result, cached := classifyCached(int(value.Int64()), classifyOptions{Debug: boolQuery(c, "debug"), Fields: mask})
+	setCacheHeader(c, cached)
+	response := exprResponse{Expression: expr, Classification: result}
+	if mask != nil {
+		renderMasked(c, http.StatusOK, response, mask)
+		return
+	}
+	stats.record(result)
+	renderEnabled(c, http.StatusOK, response)
+}
+
+// rawQueryParam reads a query param without turning "+" into a space, so
+// ?expr=2^10+23 means what it says. Percent-escapes are still decoded.
+func rawQueryParam(c *gin.Context, key string) (string, bool) {
+	for _, pair := range strings.Split(c.Request.URL.RawQuery, "&") {
+		k, v, _ := strings.Cut(pair, "=")
+		if k != key {
+			continue
+		}
+		value, err := url.PathUnescape(v)
+		if err != nil {
+			return v, true
+		}
+		return value, true
+	}
+	return "", false
+}
+
+var errDivisionByZero = errors.New("division by zero")
+
+// exprParser is a recursive-descent evaluator over integers. The grammar is
+//
+//	expr  = term {("+" | "-") term}
+//	term  = unary {("*" | "/" | "%") unary}
+//	unary = ("+" | "-") unary | power
+//	power = atom ["^" unary]
+//	atom  = digits | "(" expr ")"
+//
+// so ^ binds tightest and is right-associative, and -2^2 is -4. Division
+// truncates toward zero. There are no functions or variables.
+type exprParser struct {
+	src   string
+	pos   int
+	depth int
+}
+
+// evalExpression parses and evaluates src.
+func evalExpression(src string) (*big.Int, error) {
+	p := &exprParser{src: src}
+	value, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	return value, nil
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid expression at position %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// accept consumes the next non-space byte if it is one of ops.
+func (p *exprParser) accept(ops string) (byte, bool) {
+	p.skipSpace()
+	if p.pos < len(p.src) && strings.IndexByte(ops, p.src[p.pos]) >= 0 {
+		p.pos++
+		return p.src[p.pos-1], true
+	}
+	return 0, false
+}
+
+func (p *exprParser) expr() (*big.Int, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if op == '+' {
+			left.Add(left, right)
+		} else {
+			left.Sub(left, right)
+		}
+		if err := p.checkSize(left); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *exprParser) term() (*big.Int, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*/%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case op == '*':
+			left.Mul(left, right)
+		case right.Sign() == 0:
+			return nil, errDivisionByZero
+		case op == '/':
+			left.Quo(left, right)
+		default:
+			left.Rem(left, right)
+		}
+		if err := p.checkSize(left); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *exprParser) unary() (*big.Int, error) {
+	op, ok := p.accept("+-")
+	if !ok {
+		return p.power()
+	}
+	if p.depth++; p.depth > exprMaxDepth {
+		return nil, p.errorf("nested too deeply")
+	}
+	defer func() { p.depth-- }()
+	value, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if op == '-' {
+		value.Neg(value)
+	}
+	return value, nil
+}
+
+func (p *exprParser) power() (*big.Int, error) {
+	base, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("^"); !ok {
+		return base, nil
+	}
+	exp, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if exp.Sign() < 0 {
+		return nil, p.errorf("negative exponents are not supported")
+	}
+	// Refuse before computing when the result would be too big
+	if bits := base.BitLen(); bits > 1 && (!exp.IsInt64() || exp.Int64() > exprMaxBits || int64(bits-1)*exp.Int64() > exprMaxBits) {
+		return nil, p.errorf("result is too large")
+	}
+	base.Exp(base, exp, nil)
+	return base, p.checkSize(base)
+}
+
+func (p *exprParser) atom() (*big.Int, error) {
+	if _, ok := p.accept("("); ok {
+		if p.depth++; p.depth > exprMaxDepth {
+			return nil, p.errorf("nested too deeply")
+		}
+		value, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		p.depth--
+		return value, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos == len(p.src) {
+			return nil, p.errorf("unexpected end of expression")
+		}
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	value, _ := new(big.Int).SetString(p.src[start:p.pos], 10)
+	return value, p.checkSize(value)
+}
+
+// checkSize rejects values beyond exprMaxBits.
+func (p *exprParser) checkSize(v *big.Int) error {
+	if v.BitLen() > exprMaxBits {
+		return p.errorf("result is too large")
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fibSeeds holds the first two terms of each supported sequence.
+var fibSeeds = map[string][2]int64{
+	"fibonacci": {0, 1},
+	"lucas":     {2, 1},
+}
+
+// fibTerm is the body of GET /api/fib.
+type fibTerm struct {
+	N      int    `json:"n"`
+	Type   string `json:"type"`
+	Value  string `json:"value"` // Decimal, as a string so huge terms stay exact
+	Digits int    `json:"digits"`
+}
+
+// fibIndex is the body of GET /api/fib-index.
+type fibIndex struct {
+	Number int    `json:"number"`
+	Type   string `json:"type"`
+	Index  int    `json:"index"` // Smallest n with term n = number, or -1
+}
+
+// pisanoPeriod is the body of GET /api/pisano.
+type pisanoPeriod struct {
+	Mod    int `json:"mod"`
+	Period int `json:"period"` // Length of the cycle of Fibonacci numbers mod mod
+}
+
+// fibonacciTerm returns the nth Fibonacci or Lucas number.
+func fibonacciTerm(c *gin.Context) {
+	kind, ok := fibTypeQuery(c)
+	if !ok {
+		return
+	}
+	n, ok := intQuery(c, "n", 0)
+	if !ok {
+		return
+	}
+	if n < 0 || n > cfg.FibMaxN {
+		respondError(c, http.StatusBadRequest, c.Query("n"), fmt.Sprintf("n must be between 0 and %d", cfg.FibMaxN))
+		return
+	}
+
+	value := nthTerm(kind, n).String()
+	render(c, http.StatusOK, fibTerm{N: n, Type: kind, Value: value, Digits: len(strings.TrimPrefix(value, "-"))})
+}
+
+// fibonacciIndex finds where a number appears in the Fibonacci or Lucas sequence.
+func fibonacciIndex(c *gin.Context) {
+	kind, ok := fibTypeQuery(c)
+	if !ok {
+		return
+	}
+	number, ok := numberQuery(c)
+	if !ok {
+		return
+	}
+	render(c, http.StatusOK, fibIndex{Number: number, Type: kind, Index: termIndex(kind, number)})
+}
+
+// pisano returns the Pisano period of mod, the length of the cycle the
+// Fibonacci numbers go through modulo mod: 60 for 10, as the last digits
+// repeat every 60 terms.
+func pisano(c *gin.Context) {
+	mod, ok := numberParam(c, "mod")
+	if !ok {
+		return
+	}
+	if mod < 1 || mod > cfg.PisanoMaxMod {
+		respondError(c, http.StatusBadRequest, c.Query("mod"), fmt.Sprintf("mod must be between 1 and %d", cfg.PisanoMaxMod))
+		return
+	}
+	period, ok := pisanoPeriodOf(mod)
+	if !ok {
+		respondError(c, http.StatusInternalServerError, c.Query("mod"), "no period found within 6 × mod terms")
+		return
+	}
+	render(c, http.StatusOK, pisanoPeriod{Mod: mod, Period: period})
+}
+
+// pisanoPeriodOf steps through the Fibonacci numbers mod m until the seed
+// pair (0, 1) comes back. The period never exceeds 6m, so the search stops
+// there rather than loop forever should that ever fail; ok is false then.
+// Terms stay below m, so their sum can't overflow.
+func pisanoPeriodOf(m int) (period int, ok bool) {
+	seeds := fibSeeds["fibonacci"]
+	first, second := int(seeds[0])%m, int(seeds[1])%m // (0, 0) for m = 1, period 1
+	a, b := first, second
+	for i := 1; i <= 6*m; i++ {
+		a, b = b, (a+b)%m
+		if a == first && b == second {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// fibTypeQuery reads ?type=, defaulting to fibonacci.
+func fibTypeQuery(c *gin.Context) (string, bool) {
+	kind := strings.ToLower(c.DefaultQuery("type", "fibonacci"))
+	if _, ok := fibSeeds[kind]; !ok {
+		respondError(c, http.StatusBadRequest, kind, "type must be fibonacci or lucas")
+		return "", false
+	}
+	recordInput(c, "type", kind)
+	return kind, true
+}
+
+// nthTerm computes term n by fast doubling on Fibonacci numbers, using
+// L(n) = F(n-1) + F(n+1) for Lucas numbers.
+func nthTerm(kind string, n int) *big.Int {
+	f, f1 := fibPair(n) // F(n), F(n+1)
+	if kind == "lucas" {
+		// F(n-1) = F(n+1) - F(n), so L(n) = 2F(n+1) - F(n)
+		return new(big.Int).Sub(new(big.Int).Lsh(f1, 1), f)
+	}
+	return f
+}
+
+// fibPair returns F(n) and F(n+1) using F(2k) = F(k)(2F(k+1) - F(k)) and
+// F(2k+1) = F(k)² + F(k+1)².
+func fibPair(n int) (*big.Int, *big.Int) {
+	if n == 0 {
+		return big.NewInt(0), big.NewInt(1)
+	}
+	a, b := fibPair(n / 2)
+	c := new(big.Int).Sub(new(big.Int).Lsh(b, 1), a)
+	c.Mul(c, a)
+	d := new(big.Int).Add(new(big.Int).Mul(a, a), new(big.Int).Mul(b, b))
+	if n%2 == 0 {
+		return c, d
+	}
+	return d, c.Add(c, d)
+}
+
+// termIndex returns the smallest n whose term equals number, or -1. Both
+// sequences increase from n = 1 and pass the int64 range near n = 92, so
+// the walk is short.
+func termIndex(kind string, number int) int {
+	seeds := fibSeeds[kind]
+	a, b, target := seeds[0], seeds[1], int64(number)
+	for i := 0; ; i++ {
+		switch {
+		case a == target:
+			return i
+		case i > 0 && a > target:
+			return -1
+		case b > math.MaxInt64-a: // The term after b overflows, so b is the last to check
+			if b == target {
+				return i + 1
+			}
+			return -1
+		}
+		a, b = b, a+b
+	}
+}
+
+// isFibonacci checks if n is a Fibonacci number.
+func isFibonacci(n int) bool { return termIndex("fibonacci", n) >= 0 }
+
+// isLucas checks if n is a Lucas number.
+func isLucas(n int) bool { return termIndex("lucas", n) >= 0 }
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fieldMask is a parsed ?fields= mask. Each key is a JSON field name; a nil
+// value selects the whole field, otherwise only the listed subfields. A nil
+// mask selects everything.
+type fieldMask map[string]fieldMask
+
+// wants reports whether any of the named top-level fields is selected.
+func (m fieldMask) wants(names ...string) bool {
+	if m == nil {
+		return true
+	}
+	for _, name := range names {
+		if _, ok := m[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFieldMask parses a Google-style mask such as
+// "number,is_prime,verbose.words", where dots select nested fields.
+func parseFieldMask(raw string) fieldMask {
+	mask := fieldMask{}
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		node := mask
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			child, seen := node[part]
+			if i == len(parts)-1 || (seen && child == nil) {
+				node[part] = nil // The whole field, which covers any subfield
+				break
+			}
+			if child == nil {
+				child = fieldMask{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return mask
+}
+
+// fieldsQuery reads ?fields= for a response of type t, writing a 400 that
+// lists the valid names when a field is unknown. It returns a nil mask when
+// the param is absent.
+func fieldsQuery(c *gin.Context, t reflect.Type) (fieldMask, bool) {
+	raw, present := c.GetQuery("fields")
+	if !present {
+		return nil, true
+	}
+	mask := parseFieldMask(raw)
+	if len(mask) == 0 {
+		respondError(c, http.StatusBadRequest, raw, "fields must name at least one field")
+		return nil, false
+	}
+	if path, valid, ok := checkFieldMask(mask, t, ""); !ok {
+		render(c, http.StatusBadRequest, gin.H{
+			"field":        sanitizeEcho(path),
+			"error":        true,
+			"message":      "unknown field",
+			"valid_fields": valid,
+		})
+		return nil, false
+	}
+	return mask, true
+}
+
+// checkFieldMask verifies every path in mask against the JSON fields of t.
+// On failure it returns the offending path and the names valid at that level.
+func checkFieldMask(mask fieldMask, t reflect.Type, prefix string) (string, []string, bool) {
+	fields := jsonFields(t)
+	for _, name := range sortedKeys(mask) {
+		sub := mask[name]
+		field, ok := fields[name]
+		if !ok {
+			return prefix + name, sortedKeys(fields), false
+		}
+		if sub == nil {
+			continue
+		}
+		subFields := jsonFields(field)
+		if len(subFields) == 0 {
+			return prefix + name + "." + sortedKeys(sub)[0], []string{}, false // Scalar fields have no subfields
+		}
+		if path, valid, ok := checkFieldMask(sub, field, prefix+name+"."); !ok {
+			return path, valid, false
+		}
+	}
+	return "", nil, true
+}
+
+// jsonFields maps the JSON names of a struct's fields to their types,
+// looking through pointers and slices and flattening embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	fields := map[string]reflect.Type{}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			for name, ft := range jsonFields(f.Type) {
+				fields[name] = ft
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if hidesDisabled(t) && disabledFields[name] {
+			continue // Not part of this deployment's API
+		}
+		if name != "" && name != "-" && f.IsExported() {
+			fields[name] = f.Type
+		}
+	}
+	return fields
+}
+
+// applyFieldMask keeps only the masked fields of a decoded tree. Arrays of
+// objects are masked element by element.
+func applyFieldMask(tree interface{}, mask fieldMask) interface{} {
+	if mask == nil {
+		return tree
+	}
+	switch v := tree.(type) {
+	case orderedObject:
+		out := orderedObject{}
+		for _, f := range v {
+			if sub, ok := mask[f.Key]; ok {
+				out = append(out, orderedField{Key: f.Key, Value: applyFieldMask(f.Value, sub)})
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = applyFieldMask(item, mask)
+		}
+		return out
+	}
+	return tree
+}
+
+// renderMasked renders v with only the fields selected by mask.
+func renderMasked(c *gin.Context, status int, v interface{}, mask fieldMask) {
+	masked, err := maskValue(v, mask)
+	if err != nil {
+		render(c, http.StatusInternalServerError, gin.H{"error": true, "message": "failed to format response"})
+		return
+	}
+	render(c, status, masked)
+}
+
+// maskValue returns v with only the fields selected by mask.
+func maskValue(v interface{}, mask fieldMask) (interface{}, error) {
+	if mask == nil {
+		return v, nil
+	}
+	tree, err := toOrderedTree(v)
+	if err != nil {
+		return nil, err
+	}
+	return applyFieldMask(tree, mask), nil
+}
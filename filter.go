@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// filterRequest is the body of POST /api/filter.
+type filterRequest struct {
+	Numbers  []int  `json:"numbers"`
+	Property string `json:"property"`
+}
+
+// filterResponse lists the submitted numbers that have the property.
+type filterResponse struct {
+	Property string `json:"property"`
+	Total    int    `json:"total"` // How many numbers were submitted
+	Matches  []int  `json:"matches"`
+}
+
+// filterNumbers returns only the submitted numbers having the requested
+// property, in their original order. Like /api/nearest, it refuses numbers
+// past SEARCH_MAX_NUMBER for factored properties and stops at SEARCH_TIMEOUT.
+func filterNumbers(c *gin.Context) {
+	var req filterRequest
+	if !decodeJSONBody(c, &req) {
+		return
+	}
+	name, check, ok := resolveProperty(c, req.Property)
+	if !ok {
+		return
+	}
+	if len(req.Numbers) > cfg.FilterMaxNumbers {
+		render(c, http.StatusBadRequest, gin.H{
+			"error":   true,
+			"message": "too many numbers",
+			"max":     cfg.FilterMaxNumbers,
+		})
+		return
+	}
+
+	for _, n := range req.Numbers {
+		if !checkSearchBound(c, name, n) {
+			return
+		}
+	}
+
+	ctx, cancel := searchContext(c)
+	defer cancel()
+	matches := []int{}
+	for _, n := range req.Numbers {
+		if err := ctx.Err(); err != nil {
+			respondSearchStopped(c, err)
+			return
+		}
+		if check(n) {
+			matches = append(matches, n)
+		}
+	}
+
+	render(c, http.StatusOK, filterResponse{Property: name, Total: len(req.Numbers), Matches: matches})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// postFilter serves a POST /api/filter with the given JSON body.
+func postFilter(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/filter", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	testRouter.ServeHTTP(w, req)
+	return w
+}
+
+func TestFilterSearchBound(t *testing.T) {
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{`{"numbers": [7, 1000000000000000000], "property": "carmichael"}`, http.StatusBadRequest},
+		{`{"numbers": [7, -1000000000000000000], "property": "prime"}`, http.StatusBadRequest},
+		{`{"numbers": [7, 1000000000000], "property": "prime"}`, http.StatusOK},
+		{`{"numbers": [7, 1000000000000000000], "property": "palindrome"}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if w := postFilter(t, tt.body); w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.body, w.Code, tt.status, w.Body)
+		}
+	}
+}
+
+func TestFilterSearchTimeout(t *testing.T) {
+	defer func(d time.Duration) { cfg.SearchTimeout = d }(cfg.SearchTimeout)
+	cfg.SearchTimeout = time.Nanosecond
+
+	if w := postFilter(t, `{"numbers": [2, 3, 4], "property": "prime"}`); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status %d, want 503: %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseFormatter serializes a response value in one output format.
+// Handlers build their typed response once and hand it to render, which
+// picks the formatter; adding a format only means registering one here.
+type ResponseFormatter interface {
+	ContentType() string
+	Format(w io.Writer, v interface{}) error
+}
+
+// formatters maps ?format= names to their implementations.
+var formatters = map[string]ResponseFormatter{
+	"json": jsonFormatter{},
+	"xml":  xmlFormatter{},
+	"text": textFormatter{},
+	"csv":  csvFormatter{},
+}
+
+// formatMediaTypes maps Accept header media types to formatter names.
+var formatMediaTypes = map[string]string{
+	"application/json": "json",
+	"application/xml":  "xml",
+	"text/xml":         "xml",
+	"text/plain":       "text",
+	"text/csv":         "csv",
+}
+
+// render writes v with the formatter requested by ?format= or the Accept
+// header, defaulting to JSON. An unknown ?format= gets a 400. A JSONP
+// callback accepted by allowJSONP takes precedence over both. Object
+// responses gain an "input" echo of the params the handler recorded.
+func render(c *gin.Context, status int, v interface{}) {
+	formatter := formatters["json"]
+	if callback := c.GetString(jsonpCallbackKey); callback != "" {
+		formatter = jsonpFormatter{callback: callback}
+	} else if name := strings.ToLower(c.Query("format")); name != "" {
+		f, ok := formatters[name]
+		if !ok {
+			// The requested format is the problem, so answer in JSON
+			writeFormatted(c, formatters["json"], http.StatusBadRequest, gin.H{
+				"format":        sanitizeEcho(name),
+				"error":         true,
+				"message":       "unsupported format",
+				"valid_formats": []string{"json", "xml", "text", "csv"},
+			})
+			return
+		}
+		formatter = f
+		recordInput(c, "format", name)
+	} else if f, ok := acceptedFormatter(c.GetHeader("Accept")); ok {
+		formatter = f
+	}
+	writeFormatted(c, formatter, status, v)
+}
+
+// writeFormatted shapes v like every response, with the error format and the
+// input echo, and writes it with formatter. v is serialized into a buffer
+// first so a formatting error can still become a JSON 500.
+func writeFormatted(c *gin.Context, formatter ResponseFormatter, status int, v interface{}) {
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, withInput(c, errorBody(c, status, v))); err != nil {
+		formatter, status = formatters["json"], http.StatusInternalServerError
+		buf.Reset()
+		formatter.Format(&buf, withInput(c, errorBody(c, status, gin.H{"error": true, "message": "failed to format response"}))) // Plain JSON of this body can't fail
+	}
+	c.Header("Content-Type", formatter.ContentType())
+	c.Status(status)
+	c.Writer.Write(buf.Bytes())
+}
+
+// acceptedFormatter returns the first formatter matching the Accept header.
+func acceptedFormatter(accept string) (ResponseFormatter, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if name, ok := formatMediaTypes[strings.ToLower(mediaType)]; ok {
+			return formatters[name], true
+		}
+		if vendorMediaType.MatchString(strings.ToLower(mediaType)) {
+			return formatters["json"], true // Versioned JSON, see apiVersion
+		}
+	}
+	return nil, false
+}
+
+// jsonFormatter writes plain JSON, the default format.
+type jsonFormatter struct{}
+
+func (jsonFormatter) ContentType() string { return "application/json; charset=utf-8" }
+
+func (jsonFormatter) Format(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// xmlFormatter writes the response as XML under a <response> root, with
+// array elements as repeated <item> children.
+type xmlFormatter struct{}
+
+func (xmlFormatter) ContentType() string { return "application/xml; charset=utf-8" }
+
+func (xmlFormatter) Format(w io.Writer, v interface{}) error {
+	tree, err := toOrderedTree(v)
+	if err != nil {
+		return err
+	}
+	io.WriteString(w, xml.Header)
+	enc := xml.NewEncoder(w)
+	if err := encodeXML(enc, "response", tree); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+// encodeXML writes value as an element called name.
+func encodeXML(enc *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case orderedObject:
+		for _, f := range v {
+			if err := encodeXML(enc, f.Key, f.Value); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := encodeXML(enc, "item", item); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := enc.EncodeToken(xml.CharData(scalarString(v))); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// textFormatter writes one "key: value" line per field, with nested keys
+// joined by dots and scalar arrays joined by commas.
+type textFormatter struct{}
+
+func (textFormatter) ContentType() string { return "text/plain; charset=utf-8" }
+
+func (textFormatter) Format(w io.Writer, v interface{}) error {
+	tree, err := toOrderedTree(v)
+	if err != nil {
+		return err
+	}
+	for _, f := range flatten("", tree, ", ") {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", f.Key, f.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvFormatter writes a header row of flattened field names followed by one
+// row per record. A top-level array of objects becomes one row per element.
+type csvFormatter struct{}
+
+func (csvFormatter) ContentType() string { return "text/csv; charset=utf-8" }
+
+func (csvFormatter) Format(w io.Writer, v interface{}) error {
+	tree, err := toOrderedTree(v)
+	if err != nil {
+		return err
+	}
+	records, ok := tree.([]interface{})
+	if !ok {
+		records = []interface{}{tree}
+	}
+
+	cw := csv.NewWriter(w)
+	for i, record := range records {
+		header, row := csvRow(record)
+		if i == 0 {
+			cw.Write(header)
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvRow flattens one decoded record into its column names and values.
+func csvRow(record interface{}) (header, row []string) {
+	fields := flatten("", record, ";")
+	header, row = make([]string, len(fields)), make([]string, len(fields))
+	for i, f := range fields {
+		header[i], row[i] = f.Key, f.Value.(string)
+	}
+	return header, row
+}
+
+// orderedField is one key/value pair of a JSON object.
+type orderedField struct {
+	Key   string
+	Value interface{}
+}
+
+// orderedObject is a decoded JSON object that keeps its keys in document order.
+type orderedObject []orderedField
+
+// MarshalJSON writes the object with its keys in order.
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, f := range o {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(buf, key...), ':'), value...)
+	}
+	return append(buf, '}'), nil
+}
+
+// toOrderedTree converts v to its JSON form and decodes it into objects,
+// arrays and scalars while preserving field order, so every non-JSON
+// formatter sees the same field names and order as the JSON output.
+func toOrderedTree(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeOrdered(dec)
+}
+
+// decodeOrdered reads the next JSON value from dec.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil // string, json.Number, bool or nil
+	}
+
+	switch delim {
+	case '{':
+		obj := orderedObject{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, orderedField{Key: key.(string), Value: value})
+		}
+		_, err = dec.Token() // Consume the closing brace
+		return obj, err
+	default:
+		arr := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err = dec.Token() // Consume the closing bracket
+		return arr, err
+	}
+}
+
+// flatten turns a tree into dotted key/string pairs. Arrays of scalars are
+// joined with sep; arrays containing objects are indexed ("pair.0").
+func flatten(prefix string, value interface{}, sep string) []orderedField {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch v := value.(type) {
+	case orderedObject:
+		out := []orderedField{}
+		for _, f := range v {
+			out = append(out, flatten(join(f.Key), f.Value, sep)...)
+		}
+		return out
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			switch item.(type) {
+			case orderedObject, []interface{}:
+				out := []orderedField{}
+				for i, item := range v {
+					out = append(out, flatten(join(fmt.Sprint(i)), item, sep)...)
+				}
+				return out
+			}
+			parts = append(parts, scalarString(item))
+		}
+		return []orderedField{{Key: prefix, Value: strings.Join(parts, sep)}}
+	default:
+		return []orderedField{{Key: prefix, Value: scalarString(v)}}
+	}
+}
+
+// scalarString renders a decoded JSON scalar; null becomes an empty string.
+func scalarString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
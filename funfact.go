@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Fun fact modes.
+const (
+	funFactMath   = "math"   // Live facts from Numbers API
+	funFactStatic = "static" // Deterministic facts built locally, for CI and offline demos
+)
+
+// funFactSlots bounds the outbound Numbers API requests in flight across
+// all callers, so a traffic spike can't flood the upstream.
+var funFactSlots = make(chan struct{}, max(cfg.FunFactMaxInFlight, 1))
+
+// funFactClient is shared by every fact lookup. There is a single upstream
+// host, so the per-host idle pool is as large as the overall one and
+// concurrent lookups reuse warm keep-alive connections.
+var funFactClient = &http.Client{
+	Timeout: cfg.FunFactTimeout,
+	Transport: &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         (&net.Dialer{Timeout: 2 * time.Second, KeepAlive: 30 * time.Second}).DialContext,
+		MaxIdleConns:        cfg.FunFactIdleConns,
+		MaxIdleConnsPerHost: cfg.FunFactIdleConns,
+		IdleConnTimeout:     cfg.FunFactIdleTimeout,
+	},
+}
+
+// factTypes are the Numbers API categories ?fact_types= accepts. The first
+// is the default, and the category of fun facts in static mode.
+var factTypes = []string{"math", "trivia", "year"}
+
+// Where a fun fact came from, reported as fun_fact_source.
+const (
+	factFromNumbersAPI = "numbersapi" // Fetched live for this request
+	factFromCache      = "cache"      // Warmed ahead of time or precomputed
+	factFromFallback   = "fallback"   // The "interesting number" template; fun_fact_error says why
+	factFromStatic     = "static"     // Built from the classification in static mode
+)
+
+// Why the fallback was used, reported as fun_fact_error.
+const (
+	factErrTimeout  = "timeout"        // The upstream, a free slot or the client ran out of time
+	factErrUpstream = "upstream_error" // A transport failure or a status other than 200
+	factErrDecode   = "decode_error"   // A body that isn't a JSON fact
+	factErrNoFact   = "no_fact"        // A JSON body without text, or a category static mode can't build
+)
+
+// factOrigin is where a fun fact came from and, for a fallback, why.
+type factOrigin struct {
+	source string
+	reason string // Only set for a fallback
+}
+
+// apply reports the origin on r as fun_fact_source and fun_fact_error.
+func (o factOrigin) apply(r *Classification) {
+	r.FunFactSource = o.source
+	if o.reason != "" {
+		reason := o.reason
+		r.FunFactError = &reason
+	}
+}
+
+// funFact returns the math fun fact for a classification.
+func funFact(ctx context.Context, r Classification) string {
+	fact, _ := funFactOf(ctx, r, factTypes[0])
+	return fact
+}
+
+// funFactOf returns a fun fact of the given category: fetched live, or in
+// static mode built from the classification itself. Static mode only knows
+// math facts, so other categories get their fallback. It also reports where
+// the fact came from.
+func funFactOf(ctx context.Context, r Classification, factType string) (string, factOrigin) {
+	fallback := fmt.Sprintf("%d is an interesting number!", r.Number)
+	if factType == "year" {
+		fallback = fmt.Sprintf("%d is an interesting year!", r.Number)
+	}
+	switch {
+	case cfg.FunFactMode != funFactStatic:
+		return fetchFact(ctx, funFactPath(r.Number, factType), fallback)
+	case factType == factTypes[0]:
+		return staticFunFact(r), factOrigin{source: factFromStatic}
+	}
+	return fallback, factOrigin{source: factFromFallback, reason: factErrNoFact}
+}
+
+// funFactsOf fetches a fun fact of each category concurrently, keyed by
+// category. Each fetch takes its own outbound slot and falls back on its own.
+// Each fact's origin is keyed by category too.
+func funFactsOf(ctx context.Context, r Classification, types []string) (map[string]string, map[string]factOrigin) {
+	facts := make(map[string]string, len(types))
+	origins := make(map[string]factOrigin, len(types))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, factType := range types {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fact, origin := funFactOf(ctx, r, factType)
+			mu.Lock()
+			facts[factType], origins[factType] = fact, origin
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return facts, origins
+}
+
+// funFactPath is the Numbers API path of n's fact of the given category.
+func funFactPath(n int, factType string) string {
+	return fmt.Sprintf("%d/%s", n, factType)
+}
+
+// factTypesQuery reads ?fact_types=, a comma-separated list of categories.
+// It returns nil, meaning just the math fact, when the param is absent.
+func factTypesQuery(c *gin.Context) ([]string, bool) {
+	raw, present := c.GetQuery("fact_types")
+	if !present {
+		return nil, true
+	}
+	var types []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(factTypes, name) {
+			render(c, http.StatusBadRequest, gin.H{
+				"fact_type":        sanitizeEcho(name),
+				"error":            true,
+				"message":          "unknown fact type",
+				"valid_fact_types": factTypes,
+			})
+			return nil, false
+		}
+		if !slices.Contains(types, name) {
+			types = append(types, name)
+		}
+	}
+	recordInput(c, "fact_types", types)
+	return types, true
+}
+
+// getDateFact fetches a fact about a day of the year using Numbers API, or
+// in static mode states where the day falls in the year.
+func getDateFact(ctx context.Context, month time.Month, day int) string {
+	if cfg.FunFactMode == funFactStatic {
+		leapDay := time.Date(2000, month, day, 0, 0, 0, 0, time.UTC).YearDay() // 2000 was a leap year
+		switch {
+		case month == time.February && day == 29:
+			return fmt.Sprintf("February 29 is day %d of the year, and only exists in leap years.", leapDay)
+		case month < time.March:
+			return fmt.Sprintf("%s %d is day %d of every year.", month, day, leapDay)
+		}
+		return fmt.Sprintf("%s %d is day %d of the year, or day %d in leap years.", month, day, leapDay-1, leapDay)
+	}
+	fact, _ := fetchFact(ctx, fmt.Sprintf("%d/%d/date", month, day), fmt.Sprintf("%s %d is an interesting day!", month, day))
+	return fact
+}
+
+// errFunFactSaturated is returned when every outbound slot stays busy for
+// FunFactQueueWait.
+var errFunFactSaturated = errors.New("all fun fact request slots are busy")
+
+// errNoFactText is a Numbers API response that parses but holds no fact.
+var errNoFactText = errors.New("Numbers API response has no text")
+
+// fetchFact gets the text of a Numbers API fact, or fallback on any error.
+// Warmed facts come straight from funFactCache. It reports where the text
+// came from, and why when it is the fallback. Concurrent lookups of the
+// same fact share one request. When every slot stays busy for
+// FunFactQueueWait it falls back immediately, and cancelling ctx stops
+// waiting for the request, which finishes for any other callers.
+func fetchFact(ctx context.Context, path, fallback string) (string, factOrigin) {
+	if fact, ok := funFactCache.get(path); ok {
+		return fact, factOrigin{source: factFromCache}
+	}
+	ch := funFactFlight.DoChan(path, func() (interface{}, error) {
+		return requestFact(context.WithoutCancel(ctx), path)
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return fallback, fallbackOrigin(res.Err)
+		}
+		return res.Val.(string), factOrigin{source: factFromNumbersAPI}
+	case <-ctx.Done():
+		return fallback, factOrigin{source: factFromFallback, reason: factErrTimeout}
+	}
+}
+
+// fallbackOrigin names why requestFact failed.
+func fallbackOrigin(err error) factOrigin {
+	origin := factOrigin{source: factFromFallback, reason: factErrUpstream}
+	var failure *upstreamFailure
+	switch {
+	case errors.Is(err, errNoFactText):
+		origin.reason = factErrNoFact
+	case errors.As(err, &failure) && failure.outcome == upstreamDecodeError:
+		origin.reason = factErrDecode
+	case errors.As(err, &failure) && failure.outcome == upstreamTimeout,
+		errors.Is(err, errFunFactSaturated), errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		origin.reason = factErrTimeout
+	}
+	return origin
+}
+
+// upstreamFailure is a failed Numbers API request, with its metrics outcome.
+type upstreamFailure struct {
+	outcome string
+	err     error
+}
+
+func (f *upstreamFailure) Error() string { return f.err.Error() }
+func (f *upstreamFailure) Unwrap() error { return f.err }
+
+// requestFact makes one Numbers API request and returns the fact's text.
+func requestFact(ctx context.Context, path string) (string, error) {
+	timer := time.NewTimer(cfg.FunFactQueueWait)
+	defer timer.Stop()
+	select {
+	case funFactSlots <- struct{}{}:
+	case <-timer.C:
+		funFactSaturated.Inc()
+		return "", errFunFactSaturated
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	funFactInFlight.Inc()
+	defer func() {
+		funFactInFlight.Dec()
+		<-funFactSlots
+	}()
+
+	start := time.Now()
+	fact, outcome, err := callNumbersAPI(ctx, path)
+	funFactUpstreamDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+	funFactUpstreamRequests.WithLabelValues(outcome).Inc()
+	if err != nil {
+		return "", &upstreamFailure{outcome: outcome, err: err}
+	}
+	return fact, nil
+}
+
+// Outcomes of a Numbers API request, as labeled in the upstream metrics.
+const (
+	upstreamOK          = "ok"
+	upstreamTimeout     = "timeout"      // FUN_FACT_TIMEOUT or a dial/read timeout
+	upstreamError       = "error"        // Any other transport failure
+	upstreamBadStatus   = "bad_status"   // A response other than 200
+	upstreamDecodeError = "decode_error" // A 200 whose body isn't a JSON fact
+)
+
+// callNumbersAPI sends the HTTP request for requestFact and classifies how
+// it went for the upstream metrics. The request gets its own client span,
+// whose traceparent it carries upstream.
+func callNumbersAPI(ctx context.Context, path string) (fact, outcome string, err error) {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			funFactConnections.WithLabelValues(strconv.FormatBool(info.Reused)).Inc()
+		},
+	}
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodGet, "http://numbersapi.com/"+path+"?json", nil)
+	if err != nil {
+		return "", upstreamError, err
+	}
+	req, span := startUpstreamSpan(req)
+	status := 0
+	defer func() { endUpstreamSpan(span, status, outcome, err) }()
+	resp, err := funFactClient.Do(req)
+	if err != nil {
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+			return "", upstreamTimeout, err
+		}
+		return "", upstreamError, err
+	}
+	status = resp.StatusCode
+	defer func() {
+		io.Copy(io.Discard, resp.Body) // Drain so the connection goes back to the pool
+		resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", upstreamBadStatus, fmt.Errorf("Numbers API returned %s", resp.Status)
+	}
+
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return "", upstreamTimeout, err // The body stalled past the client timeout
+		}
+		return "", upstreamDecodeError, err
+	}
+
+	if fact, exists := result["text"].(string); exists {
+		return fact, upstreamOK, nil
+	}
+
+	return "", upstreamDecodeError, errNoFactText
+}
+
+// staticFunFact picks the most notable property already computed for r and
+// states it, so the fact never needs the network and never contradicts the
+// rest of the response. classify runs every check in this mode, even under a
+// field mask, so the choice doesn't depend on which fields were requested.
+func staticFunFact(r Classification) string {
+	n := r.Number
+	switch {
+	case r.IsPerfect:
+		return fmt.Sprintf("%d is a perfect number: it equals the sum of its proper divisors.", n)
+	case r.IsCarmichael:
+		return fmt.Sprintf("%d is a Carmichael number: a composite that passes Fermat's primality test for every coprime base.", n)
+	case r.IsPrime:
+		return fmt.Sprintf("%d is a prime number: its only divisors are 1 and itself.", n)
+	case n >= 10 && slices.Contains(r.Properties, "armstrong"):
+		return fmt.Sprintf("%d is an Armstrong number: it equals the sum of its digits, each raised to the number of digits.", n)
+	case r.IsSphenic:
+		return fmt.Sprintf("%d is a sphenic number: the product of three distinct primes.", n)
+	case r.IsCircularPrime && n >= 10:
+		return fmt.Sprintf("%d is a circular prime: every rotation of its digits is prime too.", n)
+	case r.IsPrimorial && n > 2:
+		return fmt.Sprintf("%d is a primorial: the product of the first few prime numbers.", n)
+	case r.IsHighlyComposite && n > 2:
+		return fmt.Sprintf("%d is highly composite: it has more divisors than any smaller number.", n)
+	case r.IsAchilles:
+		return fmt.Sprintf("%d is an Achilles number: powerful, but not a perfect power.", n)
+	case r.IsPowerOfTwo:
+		return fmt.Sprintf("%d is a power of two.", n)
+	case r.SquareIndex != nil:
+		return fmt.Sprintf("%d is the square of %d.", n, *r.SquareIndex)
+	case r.TriangularIndex != nil:
+		return fmt.Sprintf("%d is a triangular number: the sum of the whole numbers from 1 to %d.", n, *r.TriangularIndex)
+	case r.IsPandigital:
+		return fmt.Sprintf("%d uses every decimal digit at least once.", n)
+	case r.IsUndulating:
+		return fmt.Sprintf("%d is undulating: its digits alternate between two values.", n)
+	case r.IsSelfNumber:
+		return fmt.Sprintf("%d is a self number: no number plus its own digit sum equals it.", n)
+	case r.Abundance != nil && *r.Abundance > 0:
+		return fmt.Sprintf("%d is an abundant number: its proper divisors add up to %d, more than itself.", n, n+*r.Abundance)
+	}
+	parity := "an even"
+	if n%2 != 0 {
+		parity = "an odd"
+	}
+	return fmt.Sprintf("%d is %s number whose digits add up to %d.", n, parity, r.DigitSum)
+}
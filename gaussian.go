@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gaussianClassification is the body of GET /api/classify-gaussian. Gaussian
+// integers a+bi have their own notion of primality, so this is a separate
+// computation from the real classifier rather than a variant of it.
+type gaussianClassification struct {
+	Number          string `json:"number"` // Canonical a+bi form
+	Real            int    `json:"real"`
+	Imaginary       int    `json:"imaginary"`
+	Norm            int    `json:"norm"` // a² + b²
+	Conjugate       string `json:"conjugate"`
+	IsGaussianPrime bool   `json:"is_gaussian_prime"`
+	IsUnit          bool   `json:"is_unit"` // 1, -1, i or -i
+}
+
+// gaussianMaxPart bounds each part so the norm always fits in an int.
+const gaussianMaxPart = math.MaxInt32
+
+// gaussianPart matches a real or imaginary part after the "i" is removed.
+var gaussianPart = regexp.MustCompile(`^[+-]?\d+$`)
+
+var (
+	errNotGaussian       = errors.New("number must be a Gaussian integer such as 3+4i, -i or 5")
+	errGaussianPartRange = errors.New("real and imaginary parts must be between -2147483647 and 2147483647")
+)
+
+// classifyGaussian reports the norm, conjugate and primality of a Gaussian
+// integer given as a+bi.
+func classifyGaussian(c *gin.Context) {
+	raw, _ := rawQueryParam(c, "number") // A literal "+" is the sign, not a space
+	if len(raw) > cfg.MaxNumberLength {
+		respondError(c, http.StatusBadRequest, truncateEcho(raw, cfg.MaxNumberLength), errTooLong.Error())
+		return
+	}
+	a, b, err := parseGaussian(raw)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, raw, err.Error())
+		return
+	}
+	recordInput(c, "number", formatGaussian(a, b))
+
+	norm := a*a + b*b
+	render(c, http.StatusOK, gaussianClassification{
+		Number:          formatGaussian(a, b),
+		Real:            a,
+		Imaginary:       b,
+		Norm:            norm,
+		Conjugate:       formatGaussian(a, -b),
+		IsGaussianPrime: isGaussianPrime(a, b),
+		IsUnit:          norm == 1,
+	})
+}
+
+// parseGaussian parses "a+bi", "a-bi", "bi", "i", "-i" or a plain integer,
+// ignoring whitespace.
+func parseGaussian(raw string) (a, b int, err error) {
+	s := strings.Join(strings.Fields(raw), "")
+	realPart, imagPart := s, "0"
+	if body, ok := strings.CutSuffix(s, "i"); ok {
+		// The imaginary part starts at the last sign that isn't the first byte
+		split := max(strings.LastIndexAny(body, "+-"), 0)
+		realPart, imagPart = body[:split], body[split:]
+		switch imagPart {
+		case "", "+":
+			imagPart = "1"
+		case "-":
+			imagPart = "-1"
+		}
+		if realPart == "" {
+			realPart = "0"
+		}
+	}
+	if !gaussianPart.MatchString(realPart) || !gaussianPart.MatchString(imagPart) {
+		return 0, 0, errNotGaussian
+	}
+	if a, err = gaussianPartValue(realPart); err != nil {
+		return 0, 0, err
+	}
+	if b, err = gaussianPartValue(imagPart); err != nil {
+		return 0, 0, err
+	}
+	return a, b, nil
+}
+
+// gaussianPartValue parses one part and checks it against gaussianMaxPart.
+func gaussianPartValue(s string) (int, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < -gaussianMaxPart || v > gaussianMaxPart {
+		return 0, errGaussianPartRange
+	}
+	return int(v), nil
+}
+
+// formatGaussian writes a+bi in its shortest form: "3+4i", "3-i", "-i", "5".
+func formatGaussian(a, b int) string {
+	imag := ""
+	switch b {
+	case 0:
+		return strconv.Itoa(a)
+	case 1:
+		imag = "i"
+	case -1:
+		imag = "-i"
+	default:
+		imag = strconv.Itoa(b) + "i"
+	}
+	if a == 0 {
+		return imag
+	}
+	if b > 0 {
+		imag = "+" + imag
+	}
+	return strconv.Itoa(a) + imag
+}
+
+// isGaussianPrime checks if a+bi is a Gaussian prime: either both parts are
+// nonzero and the norm is prime, or one part is zero and the other is ±p for
+// a prime p ≡ 3 (mod 4). 2 = (1+i)(1-i) and 5 = (2+i)(2-i) are not.
+func isGaussianPrime(a, b int) bool {
+	if a == 0 || b == 0 {
+		p := magnitude(a) + magnitude(b)
+		return p%4 == 3 && isPrime(int(p))
+	}
+	return big.NewInt(int64(a*a + b*b)).ProbablyPrime(20)
+}
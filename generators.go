@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sequenceGenerators produce the first count terms of a sequence, keyed by
+// the registry property it belongs to. Sequences only known up to the int
+// range (perfect, armstrong, highly_composite) may return fewer terms.
+var sequenceGenerators = map[string]func(count int) []*big.Int{
+	"fibonacci": func(count int) []*big.Int { return recurrenceTerms(fibSeeds["fibonacci"], count) },
+	"lucas":     func(count int) []*big.Int { return recurrenceTerms(fibSeeds["lucas"], count) },
+	"prime":     func(count int) []*big.Int { return bigTerms(firstPrimes(count)) },
+	"primorial": primorialTerms,
+	"perfect":   func(count int) []*big.Int { return bigTerms(perfectNumbers[:min(count, len(perfectNumbers))]) },
+	"armstrong": func(count int) []*big.Int { return bigTerms(armstrongNumbers[:min(count, len(armstrongNumbers))]) },
+	"highly_composite": func(count int) []*big.Int {
+		return bigTerms(highlyCompositeNumbers[:min(count, len(highlyCompositeNumbers))])
+	},
+	"square": formulaTerms(func(k *big.Int) *big.Int { return k.Mul(k, k) }),
+	"triangular": formulaTerms(func(k *big.Int) *big.Int {
+		return k.Rsh(new(big.Int).Mul(k, new(big.Int).Add(k, big.NewInt(1))), 1)
+	}),
+	"power_of_two": formulaTerms(func(k *big.Int) *big.Int { return k.Lsh(big.NewInt(1), uint(k.Uint64())) }),
+	"even":         formulaTerms(func(k *big.Int) *big.Int { return k.Lsh(k, 1) }),
+	"odd":          formulaTerms(func(k *big.Int) *big.Int { return k.Add(k.Lsh(k, 1), big.NewInt(1)) }),
+}
+
+// sequenceTerms is the body of GET /api/sequence.
+type sequenceTerms struct {
+	Name      string   `json:"name"`
+	Title     string   `json:"title"`
+	OEIS      *string  `json:"oeis"`
+	Count     int      `json:"count"`
+	Terms     []string `json:"terms"`     // Decimal, as strings so huge terms stay exact
+	Truncated bool     `json:"truncated"` // Fewer terms than asked for: the rest don't fit in an int
+}
+
+// recurrenceTerms returns terms of a(n) = a(n-1) + a(n-2) from the first two.
+func recurrenceTerms(seeds [2]int64, count int) []*big.Int {
+	terms := make([]*big.Int, 0, count)
+	a, b := big.NewInt(seeds[0]), big.NewInt(seeds[1])
+	for len(terms) < count {
+		terms = append(terms, a)
+		a, b = b, new(big.Int).Add(a, b)
+	}
+	return terms
+}
+
+// formulaTerms makes a generator of term(k) for k = 0, 1, ... . term may
+// reuse the k it is given for its result.
+func formulaTerms(term func(k *big.Int) *big.Int) func(count int) []*big.Int {
+	return func(count int) []*big.Int {
+		terms := make([]*big.Int, count)
+		for k := range terms {
+			terms[k] = term(big.NewInt(int64(k)))
+		}
+		return terms
+	}
+}
+
+// primorialTerms returns 0# = 1, 2# = 2, 3# = 6, 5# = 30, ...
+func primorialTerms(count int) []*big.Int {
+	terms := []*big.Int{big.NewInt(1)}
+	for _, p := range firstPrimes(count - 1) {
+		terms = append(terms, new(big.Int).Mul(terms[len(terms)-1], big.NewInt(int64(p))))
+	}
+	return terms
+}
+
+// bigTerms converts int terms to big.Int.
+func bigTerms(values []int) []*big.Int {
+	terms := make([]*big.Int, len(values))
+	for i, v := range values {
+		terms[i] = big.NewInt(int64(v))
+	}
+	return terms
+}
+
+// sequenceNames lists the enabled generator names in sorted order.
+func sequenceNames() []string {
+	names := []string{}
+	for name := range sequenceGenerators {
+		if !disabledProperties[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sequenceOf returns the first count terms of the named sequence.
+func sequenceOf(c *gin.Context) {
+	name := strings.ToLower(strings.TrimSpace(c.DefaultQuery("name", "fibonacci")))
+	generate, ok := sequenceGenerators[name]
+	if !ok || disabledProperties[name] {
+		render(c, http.StatusBadRequest, gin.H{
+			"name":            sanitizeEcho(name),
+			"error":           true,
+			"message":         "unknown sequence",
+			"valid_sequences": sequenceNames(),
+		})
+		return
+	}
+	recordInput(c, "name", name)
+	count, ok := intQuery(c, "count", 10)
+	if !ok {
+		return
+	}
+	if count < 1 || count > cfg.SequenceMaxCount {
+		respondError(c, http.StatusBadRequest, c.Query("count"), fmt.Sprintf("count must be between 1 and %d", cfg.SequenceMaxCount))
+		return
+	}
+
+	info := propertySequences[name]
+	result := sequenceTerms{Name: name, Title: info.Name, OEIS: info.OEIS, Terms: []string{}}
+	for _, term := range generate(count) {
+		result.Terms = append(result.Terms, term.String())
+	}
+	result.Count, result.Truncated = len(result.Terms), len(result.Terms) < count
+	render(c, http.StatusOK, result)
+}
@@ -0,0 +1,136 @@
+package main
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative numclasspb/numclass.proto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/adidazbot/num_class_api/numclasspb"
+)
+
+// grpcServer implements numclasspb.NumberClassifierServer on top of classify.
+type grpcServer struct {
+	numclasspb.UnimplementedNumberClassifierServer
+}
+
+// Classify classifies a single number.
+func (s *grpcServer) Classify(ctx context.Context, req *numclasspb.ClassifyRequest) (*numclasspb.ClassifyResponse, error) {
+	opts := classifyOptions{Context: ctx}
+	if overloaded() {
+		opts.Fields = liteMask(nil) // Only cheap fields while /api is overloaded
+		grpc.SetHeader(ctx, metadata.Pairs("x-degraded", "true"))
+	}
+	result, _, err := coalescedClassify(int(req.GetNumber()), opts)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	if opts.Fields == nil {
+		stats.record(result) // Partial results would skew the property percentages
+	}
+	return toProto(result), nil
+}
+
+// ClassifyStream classifies each number received on the stream until the client closes it.
+func (s *grpcServer) ClassifyStream(stream numclasspb.NumberClassifier_ClassifyStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		opts := classifyOptions{Context: stream.Context()}
+		if overloaded() {
+			opts.Fields = liteMask(nil) // Only cheap fields while /api is overloaded
+			stream.SetTrailer(metadata.Pairs("x-degraded", "true"))
+		}
+		result := classify(int(req.GetNumber()), opts)
+		if opts.Fields == nil {
+			stats.record(result)
+		}
+		if err := stream.Send(toProto(result)); err != nil {
+			return err
+		}
+	}
+}
+
+// toProto converts a Classification into its protobuf message.
+func toProto(result Classification) *numclasspb.ClassifyResponse {
+	resp := &numclasspb.ClassifyResponse{
+		Number:               int64(result.Number),
+		IsPrime:              result.IsPrime,
+		IsPerfect:            result.IsPerfect,
+		IsPractical:          result.IsPractical,
+		IsPowerOfTwo:         result.IsPowerOfTwo,
+		IsTriangular:         result.IsTriangular,
+		IsSquare:             result.IsSquare,
+		IsEvil:               result.IsEvil,
+		IsOdious:             result.IsOdious,
+		Properties:           result.Properties,
+		DigitSum:             int64(result.DigitSum),
+		FunFact:              result.FunFact,
+		IsCarmichael:         result.IsCarmichael,
+		IsSelfNumber:         result.IsSelfNumber,
+		IsSphenic:            result.IsSphenic,
+		IsPandigital:         result.IsPandigital,
+		IsZerolessPandigital: result.IsZerolessPandigital,
+		Sign:                 result.Sign,
+		Undefined:            result.Undefined,
+		IsAchilles:           result.IsAchilles,
+		IsUndulating:         result.IsUndulating,
+		IsCircularPrime:      result.IsCircularPrime,
+		IsPrimorial:          result.IsPrimorial,
+		IsDuffinian:          result.IsDuffinian,
+		IsHoax:               result.IsHoax,
+		IsKeith:              result.IsKeith,
+		IsDisarium:           result.IsDisarium,
+		IsBinaryPalindrome:   result.IsBinaryPalindrome,
+		IsHighlyComposite:    result.IsHighlyComposite,
+		FunFactSource:        result.FunFactSource,
+	}
+	resp.Reversed = optionalInt64(result.Reversed)
+	resp.TriangularIndex = optionalInt64(result.TriangularIndex)
+	resp.SquareIndex = optionalInt64(result.SquareIndex)
+	resp.Abundance = optionalInt64(result.Abundance)
+	resp.DigitEconomy = result.DigitEconomy
+	resp.FunFactError = result.FunFactError
+	return resp
+}
+
+// startGRPCServer serves the gRPC API on the given port in the background.
+func startGRPCServer(port string) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(authUnaryInterceptor), grpc.StreamInterceptor(authStreamInterceptor))
+	numclasspb.RegisterNumberClassifierServer(srv, &grpcServer{})
+
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			log.Printf("gRPC server stopped: %v", err)
+		}
+	}()
+
+	log.Printf("gRPC server running on port %s...", port)
+	return srv, nil
+}
+
+// optionalInt64 converts a nullable int to a proto3 optional field.
+func optionalInt64(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	n := int64(*v)
+	return &n
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Bounds for /api/guess-base.
+const (
+	guessBaseMaxBase   = 36 // Digits 0-9 then a-z
+	guessBaseMaxDigits = 64
+)
+
+// baseInterpretation is the value of a digit string read in one base.
+type baseInterpretation struct {
+	Base  int    `json:"base"`
+	Value string `json:"value"` // Decimal, as a string so huge values stay exact
+}
+
+// guessBaseResult is the body of GET /api/guess-base.
+type guessBaseResult struct {
+	Digits          string               `json:"digits"`
+	MinBase         int                  `json:"min_base"`
+	Interpretations []baseInterpretation `json:"interpretations"`
+}
+
+// guessBase lists every base from the smallest valid one up to 36 in which
+// the digit string is a number, with its decimal value in each.
+func guessBase(c *gin.Context) {
+	digits := strings.ToLower(strings.TrimSpace(c.Query("digits")))
+	if digits == "" || len(digits) > guessBaseMaxDigits {
+		respondError(c, http.StatusBadRequest, truncateEcho(digits, guessBaseMaxDigits), "digits must be 1 to 64 characters")
+		return
+	}
+
+	// The largest digit symbol fixes the smallest base the string can be in
+	maxDigit := 0
+	for _, r := range digits {
+		d, ok := digitValue(r)
+		if !ok {
+			respondError(c, http.StatusBadRequest, digits, "digits may only contain 0-9 and a-z")
+			return
+		}
+		if d > maxDigit {
+			maxDigit = d
+		}
+	}
+	recordInput(c, "digits", digits)
+	minBase := maxDigit + 1
+	if minBase < 2 {
+		minBase = 2
+	}
+
+	result := guessBaseResult{Digits: digits, MinBase: minBase, Interpretations: []baseInterpretation{}}
+	for base := minBase; base <= guessBaseMaxBase; base++ {
+		value, _ := new(big.Int).SetString(digits, base)
+		result.Interpretations = append(result.Interpretations, baseInterpretation{Base: base, Value: value.String()})
+	}
+	render(c, http.StatusOK, result)
+}
+
+// digitValue returns the value of a digit symbol (0-9, a-z).
+func digitValue(r rune) (int, bool) {
+	switch {
+	case r >= '0' && r <= '9':
+		return int(r - '0'), true
+	case r >= 'a' && r <= 'z':
+		return int(r-'a') + 10, true
+	}
+	return 0, false
+}
+
+// truncateEcho shortens user input before echoing it in an error.
+func truncateEcho(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// draining is set once shutdown starts, so /readyz fails while in-flight
+// requests finish and the load balancer stops routing new traffic here.
+var draining atomic.Bool
+
+// startDraining flips the readiness flag, logging the transition once.
+func startDraining() {
+	if draining.CompareAndSwap(false, true) {
+		log.Println("Shutdown started: readiness now reports not ready, draining requests")
+	}
+}
+
+// healthz is the liveness probe; it stays OK for as long as the process serves.
+func healthz(c *gin.Context) {
+	render(c, http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readyz is the readiness probe; it fails as soon as the server is draining.
+func readyz(c *gin.Context) {
+	if draining.Load() {
+		render(c, http.StatusServiceUnavailable, gin.H{"status": "draining"})
+		return
+	}
+	render(c, http.StatusOK, gin.H{"status": "ready"})
+}
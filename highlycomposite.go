@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// highlyCompositeTerm is one entry of GET /api/highly-composite.
+type highlyCompositeTerm struct {
+	Number       int `json:"number"`
+	DivisorCount int `json:"divisor_count"`
+}
+
+// highlyCompositeList is the body of GET /api/highly-composite.
+type highlyCompositeList struct {
+	Count   int                   `json:"count"`
+	Numbers []highlyCompositeTerm `json:"numbers"`
+}
+
+// highlyComposite serves the first count highly composite numbers with their
+// divisor counts. count is capped at the list that fits in an int.
+func highlyComposite(c *gin.Context) {
+	count, ok := intQuery(c, "count", 10)
+	if !ok {
+		return
+	}
+	if count < 1 || count > len(highlyCompositeNumbers) {
+		respondError(c, http.StatusBadRequest, c.Query("count"), fmt.Sprintf("count must be between 1 and %d", len(highlyCompositeNumbers)))
+		return
+	}
+
+	result := highlyCompositeList{Count: count, Numbers: make([]highlyCompositeTerm, count)}
+	for i, n := range highlyCompositeNumbers[:count] {
+		result.Numbers[i] = highlyCompositeTerm{Number: n, DivisorCount: divisorCount(factorize(n))}
+	}
+	render(c, http.StatusOK, result)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiRoute is one method and path the server answers.
+type apiRoute struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// apiIndexResult is the body of GET /.
+type apiIndexResult struct {
+	Name   string     `json:"name"`
+	Docs   string     `json:"docs"`
+	Routes []apiRoute `json:"routes"`
+}
+
+// apiIndex describes the API at the root path, listing the registered routes
+// so the index can't drift from the router.
+func apiIndex(r *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		routes := []apiRoute{}
+		for _, route := range r.Routes() {
+			routes = append(routes, apiRoute{Method: route.Method, Path: route.Path})
+		}
+		sort.Slice(routes, func(i, j int) bool {
+			if routes[i].Path != routes[j].Path {
+				return routes[i].Path < routes[j].Path
+			}
+			return routes[i].Method < routes[j].Method
+		})
+		render(c, http.StatusOK, apiIndexResult{
+			Name:   "Number Classification API",
+			Docs:   "https://github.com/adidazbot/num_class_api#readme",
+			Routes: routes,
+		})
+	}
+}
+
+// notFound is the JSON 404 for unknown paths.
+func notFound(c *gin.Context) {
+	render(c, http.StatusNotFound, gin.H{
+		"path":    sanitizeEcho(c.Request.URL.Path),
+		"error":   true,
+		"message": "not found",
+	})
+}
+
+// methodNotAllowed is the JSON 405 for known paths hit with the wrong method.
+// Gin has already set the Allow header by the time it runs.
+func methodNotAllowed(c *gin.Context) {
+	render(c, http.StatusMethodNotAllowed, gin.H{
+		"method":  c.Request.Method,
+		"allowed": strings.Split(c.Writer.Header().Get("Allow"), ", "),
+		"error":   true,
+		"message": "method not allowed",
+	})
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsedInputKey is the context key for the params a handler interpreted.
+const parsedInputKey = "parsed_input"
+
+// recordInput notes a recognized param as the server interpreted it
+// (parsed, normalized or defaulted). render echoes these back as "input".
+func recordInput(c *gin.Context, key string, value interface{}) {
+	input, _ := c.Get(parsedInputKey)
+	params, ok := input.(map[string]interface{})
+	if !ok {
+		params = map[string]interface{}{}
+		c.Set(parsedInputKey, params)
+	}
+	params[key] = value
+}
+
+// withInput appends the recorded params to an object response as an
+// "input" object with sorted keys. Other responses are returned unchanged.
+func withInput(c *gin.Context, v interface{}) interface{} {
+	input, _ := c.Get(parsedInputKey)
+	params, ok := input.(map[string]interface{})
+	if !ok || len(params) == 0 {
+		return v
+	}
+	tree, err := toOrderedTree(v)
+	if err != nil {
+		return v
+	}
+	obj, ok := tree.(orderedObject)
+	if !ok {
+		return v
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	echo := make(orderedObject, 0, len(keys))
+	for _, key := range keys {
+		echo = append(echo, orderedField{Key: key, Value: params[key]})
+	}
+	return append(obj, orderedField{Key: "input", Value: echo})
+}
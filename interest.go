@@ -0,0 +1,76 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// defaultInterestWeights score each registry property by its rarity: the
+// order of magnitude of 1,000,000 / (members below a million), rounded, so
+// a property one number in ten has weighs 1 and one shared by a handful
+// weighs 5. Properties half of all numbers have (even, odd, evil, odious) or
+// five in six do (extravagant) weigh nothing, and the pandigitals, which
+// start above a billion, weigh 6.
+var defaultInterestWeights = map[string]int{
+	"prime":               1,
+	"perfect":             5,
+	"armstrong":           5,
+	"palindrome":          3,
+	"practical":           1,
+	"power_of_two":        5,
+	"triangular":          3,
+	"square":              3,
+	"carmichael":          4,
+	"self":                1,
+	"sphenic":             1,
+	"powerful":            3,
+	"perfect_power":       3,
+	"achilles":            3,
+	"undulating":          3,
+	"circular_prime":      4,
+	"primorial":           5,
+	"duffinian":           1,
+	"hoax":                1,
+	"keith":               4,
+	"disarium":            5,
+	"binary_palindrome":   3,
+	"highly_composite":    4,
+	"pandigital":          6,
+	"zeroless_pandigital": 6,
+	"fibonacci":           5,
+	"lucas":               5,
+	"frugal":              3,
+	"equidigital":         1,
+}
+
+// interestWeights are the defaults with INTEREST_WEIGHTS applied.
+var interestWeights = loadInterestWeights(cfg.InterestWeights)
+
+// loadInterestWeights overrides the default weights. An unknown property is
+// fatal, like in ENABLED_PROPERTIES, so a typo can't silently skew scores.
+// Properties the allowlist disabled are accepted but never score.
+func loadInterestWeights(overrides map[string]int) map[string]int {
+	weights := make(map[string]int, len(defaultInterestWeights))
+	for name, weight := range defaultInterestWeights {
+		weights[name] = weight
+	}
+	for name, weight := range overrides {
+		name = canonicalProperty(strings.ToLower(name))
+		if _, ok := propertyRegistry[name]; !ok && !disabledProperties[name] {
+			log.Fatalf("Unknown property %q in INTEREST_WEIGHTS; valid: %s", name, strings.Join(propertyNames(), ", "))
+		}
+		weights[name] = weight
+	}
+	return weights
+}
+
+// interestingScore adds up the weights of the registry properties n has.
+func interestingScore(n int) int {
+	score := 0
+	for name, weight := range interestWeights {
+		if check, ok := propertyRegistry[name]; ok && weight != 0 && check(n) {
+			score += weight
+		}
+	}
+	return score
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adidazbot/num_class_api/numclass"
+	"github.com/gin-gonic/gin"
+)
+
+// Job statuses.
+const (
+	jobPending = numclass.JobPending
+	jobRunning = numclass.JobRunning
+	jobDone    = numclass.JobDone
+)
+
+// job is an asynchronous batch classification: what clients see of it, plus
+// the state only the server needs.
+type job struct {
+	numclass.Job
+
+	numbers        []int
+	idempotencyKey string
+	callbackURL    string
+	callback       *callbackStatus // Delivery record; nil without a callback URL
+}
+
+// jobStore keeps jobs in memory until they expire.
+type jobStore struct {
+	mu    sync.Mutex
+	jobs  map[string]*job
+	byKey map[string]string // Idempotency-Key -> job ID, expired with the job
+	ttl   time.Duration
+}
+
+// jobs is the process-wide job store.
+var jobs = newJobStore(cfg.JobTTL)
+
+// newJobStore creates a store and starts its expiry loop.
+func newJobStore(ttl time.Duration) *jobStore {
+	s := &jobStore{jobs: map[string]*job{}, byKey: map[string]string{}, ttl: ttl}
+	go s.expireLoop()
+	return s
+}
+
+// create registers a job for numbers, or returns the existing job if key was
+// already used. created reports whether a new job was made.
+func (s *jobStore) create(numbers []int, key, callbackURL string) (snapshot numclass.Job, created bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key != "" {
+		if id, ok := s.byKey[key]; ok {
+			return s.jobs[id].snapshot(), false
+		}
+	}
+
+	now := time.Now()
+	j := &job{
+		Job: numclass.Job{
+			ID:          newJobID(),
+			Status:      jobPending,
+			Total:       len(numbers),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.ttl),
+			CallbackURL: callbackURL,
+			Dedup:       dedupOf(numbers),
+		},
+		numbers:        numbers,
+		idempotencyKey: key,
+		callbackURL:    callbackURL,
+	}
+	if callbackURL != "" {
+		j.callback = &callbackStatus{
+			JobID:       j.ID,
+			CallbackURL: callbackURL,
+			State:       callbackPending,
+			MaxAttempts: max(cfg.WebhookMaxAttempts, 1),
+			Attempts:    []callbackAttempt{},
+		}
+	}
+	s.jobs[j.ID] = j
+	if key != "" {
+		s.byKey[key] = j.ID
+	}
+
+	go s.run(j)
+	return j.snapshot(), true
+}
+
+// get returns a copy of the job with the given ID.
+func (s *jobStore) get(id string) (numclass.Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return numclass.Job{}, false
+	}
+	return j.snapshot(), true
+}
+
+// callbackStatus returns a copy of the job's callback delivery record. ok is
+// false if the job doesn't exist or has no callback.
+func (s *jobStore) callbackStatus(id string) (status callbackStatus, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, found := s.jobs[id]
+	if !found || j.callback == nil {
+		return callbackStatus{}, false
+	}
+	status = *j.callback
+	status.Attempts = append([]callbackAttempt{}, j.callback.Attempts...)
+	return status, true
+}
+
+// recordCallbackAttempt appends a failed or successful delivery attempt. A
+// non-nil next marks the callback as waiting to retry at that time.
+func (s *jobStore) recordCallbackAttempt(id string, attempt callbackAttempt, next *time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if j, ok := s.jobs[id]; ok && j.callback != nil {
+		j.callback.Attempts = append(j.callback.Attempts, attempt)
+		j.callback.NextAttemptAt = next
+		if next != nil {
+			j.callback.State = callbackRetrying
+		}
+	}
+}
+
+// finishCallback sets the final delivery state of a job's callback.
+func (s *jobStore) finishCallback(id, state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if j, ok := s.jobs[id]; ok && j.callback != nil {
+		j.callback.State = state
+		j.callback.NextAttemptAt = nil
+	}
+}
+
+// run classifies every number of the job, updating its progress as it goes.
+func (s *jobStore) run(j *job) {
+	s.mu.Lock()
+	j.Status = jobRunning
+	s.mu.Unlock()
+
+	results := make([]Classification, 0, len(j.numbers))
+	seen := make(map[int]int, j.Dedup.Unique) // Number -> index of its first result
+	for _, n := range j.numbers {
+		if first, ok := seen[n]; ok {
+			results = append(results, results[first].Clone()) // No second fun-fact lookup
+		} else {
+			seen[n] = len(results)
+			results = append(results, classify(n, classifyOptions{}))
+		}
+		s.mu.Lock()
+		j.Completed++
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	now := time.Now()
+	j.Status = jobDone
+	j.Results = results
+	j.CompletedAt = &now
+	snapshot := j.snapshot()
+	s.mu.Unlock()
+
+	// Notify the client instead of making it poll
+	if j.callbackURL != "" {
+		s.deliverCallback(snapshot, j.callbackURL)
+	}
+}
+
+// expireLoop drops expired jobs and their idempotency keys once a minute.
+func (s *jobStore) expireLoop() {
+	for range time.Tick(time.Minute) {
+		now := time.Now()
+		s.mu.Lock()
+		for id, j := range s.jobs {
+			if now.After(j.ExpiresAt) {
+				delete(s.jobs, id)
+				if j.idempotencyKey != "" {
+					delete(s.byKey, j.idempotencyKey)
+				}
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// snapshot copies what clients see of a job. Results are never mutated after
+// the job completes, so they can be shared. Callers must hold the store lock.
+func (j *job) snapshot() numclass.Job {
+	return j.Job
+}
+
+// dedupOf counts the distinct numbers of a job.
+func dedupOf(numbers []int) *numclass.JobDedup {
+	distinct := make(map[int]struct{}, len(numbers))
+	for _, n := range numbers {
+		distinct[n] = struct{}{}
+	}
+	return &numclass.JobDedup{Unique: len(distinct), Duplicates: len(numbers) - len(distinct)}
+}
+
+// withDebug leaves a job's dedup counts in only for ?debug=true.
+func withDebug(c *gin.Context, j numclass.Job) numclass.Job {
+	if !boolQuery(c, "debug") {
+		j.Dedup = nil
+	}
+	return j
+}
+
+// newJobID returns a random 128-bit hex identifier.
+func newJobID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// jobRequest is the body of POST /api/jobs.
+type jobRequest struct {
+	Numbers     []int  `json:"numbers"`
+	CallbackURL string `json:"callback_url"` // Optional; receives the results when done
+}
+
+// createJob submits a batch of numbers for asynchronous classification. A
+// repeated Idempotency-Key returns the job created by the first submission.
+func createJob(c *gin.Context) {
+	var req jobRequest
+	if !decodeJSONBody(c, &req) {
+		return
+	}
+	if len(req.Numbers) == 0 || len(req.Numbers) > cfg.JobMaxNumbers {
+		render(c, http.StatusBadRequest, gin.H{
+			"error":   true,
+			"message": "numbers must contain between 1 and the maximum allowed entries",
+			"max":     cfg.JobMaxNumbers,
+		})
+		return
+	}
+
+	if req.CallbackURL != "" {
+		if err := validateCallbackURL(req.CallbackURL); err != nil {
+			render(c, http.StatusBadRequest, gin.H{"error": true, "message": err.Error()})
+			return
+		}
+	}
+
+	j, created := jobs.create(req.Numbers, c.GetHeader("Idempotency-Key"), req.CallbackURL)
+	j = withDebug(c, j)
+	if !created {
+		renderEnabled(c, http.StatusOK, j) // Replay of an earlier submission
+		return
+	}
+	c.Header("Location", "/api/jobs/"+j.ID)
+	renderEnabled(c, http.StatusAccepted, j)
+}
+
+// getJob reports a job's progress, including its results once done.
+func getJob(c *gin.Context) {
+	j, ok := jobs.get(c.Param("id"))
+	if !ok {
+		render(c, http.StatusNotFound, gin.H{"error": true, "message": "job not found"})
+		return
+	}
+	renderEnabled(c, http.StatusOK, withDebug(c, j))
+}
+
+// getCallbackStatus reports the delivery attempts and final state of a job's
+// callback, so clients can tell a dead-lettered callback from a slow job.
+func getCallbackStatus(c *gin.Context) {
+	status, ok := jobs.callbackStatus(c.Param("id"))
+	if !ok {
+		render(c, http.StatusNotFound, gin.H{"error": true, "message": "job not found or has no callback_url"})
+		return
+	}
+	render(c, http.StatusOK, status)
+}
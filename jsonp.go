@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jsonpCallbackKey is the context key holding a validated JSONP callback.
+const jsonpCallbackKey = "jsonp_callback"
+
+// jsonpCallbackPattern accepts plain or dotted JavaScript identifiers
+// (cb, jQuery123_456, widgets.onNumber) and nothing that could break out of
+// the call expression.
+var jsonpCallbackPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]{0,63}(\.[A-Za-z_$][A-Za-z0-9_$]{0,63}){0,3}$`)
+
+// allowJSONP lets a GET route answer ?callback=name with JSONP. It validates
+// the name up front so render only ever wraps a safe identifier.
+func allowJSONP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		callback, present := c.GetQuery("callback")
+		if !present {
+			c.Next()
+			return
+		}
+		if !jsonpCallbackPattern.MatchString(callback) {
+			render(c, http.StatusBadRequest, gin.H{
+				"callback": sanitizeEcho(callback),
+				"error":    true,
+				"message":  "callback must be a JavaScript identifier",
+			})
+			c.Abort()
+			return
+		}
+		c.Set(jsonpCallbackKey, callback)
+		c.Next()
+	}
+}
+
+// jsonpFormatter wraps the JSON response in a call to the client's callback.
+type jsonpFormatter struct {
+	callback string
+}
+
+func (jsonpFormatter) ContentType() string { return "application/javascript; charset=utf-8" }
+
+func (f jsonpFormatter) Format(w io.Writer, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// The leading comment stops the body from being sniffed as another file type
+	_, err = fmt.Fprintf(w, "/**/%s(%s);", f.callback, body)
+	return err
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestJSONPCallback(t *testing.T) {
+	w := get(t, "/api/classify-number?number=28&callback=widgets.onNumber")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), "/**/widgets.onNumber({") {
+		t.Errorf("status %d, body %q", w.Code, w.Body)
+	}
+}
+
+func TestInvalidJSONPCallbackRendered(t *testing.T) {
+	w := get(t, "/api/classify-number?number=28&callback=alert(1)&format=text")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type %q, want the requested text", ct)
+	}
+	if !strings.Contains(w.Body.String(), "callback must be a JavaScript identifier") {
+		t.Errorf("body %q", w.Body)
+	}
+}
@@ -1,162 +1,205 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"crypto/tls"
+	"errors"
 	"log"
-	"math"
 	"net/http"
 	"os"
-	"strconv"
-	"strings"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
 )
 
-// classifyNumber handles number classification and returns JSON response.
-func classifyNumber(c *gin.Context) {
-	numberStr := c.Query("number") // Get number from query params
-	numberStr = strings.TrimSpace(numberStr)
-
-	// Try to parse input as a float (to handle floating-point numbers)
-	numberFloat, err := strconv.ParseFloat(numberStr, 64)
+func main() {
+	// Export spans when an OTLP collector is configured
+	stopTracing, err := startTracing(context.Background())
 	if err != nil {
-		// Return 400 Bad Request for invalid input (non-numeric)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"number": numberStr,
-			"error":  true,
-		})
-		return
+		log.Fatal("Failed to start tracing:", err)
 	}
 
-	// Convert float to an integer (truncate decimal part)
-	number := int(numberFloat)
-
-	// Determine number properties
-	properties := []string{}
-	if isArmstrong(number) {
-		properties = append(properties, "armstrong")
-	}
-	if number%2 == 0 {
-		properties = append(properties, "even")
-	} else {
-		properties = append(properties, "odd")
-	}
+	r := newRouter()
 
-	// Prepare JSON response
-	response := gin.H{
-		"number":     number,
-		"is_prime":   isPrime(number),
-		"is_perfect": isPerfect(number),
-		"properties": properties,
-		"digit_sum":  digitSum(number),
-		"fun_fact":   getFunFact(number),
+	// Configure the HTTP server with timeouts (guards against slowloris clients)
+	server := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           r,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
 	}
 
-	// Return successful response
-	c.JSON(http.StatusOK, response)
-}
-
-// isPrime checks if a number is prime.
-func isPrime(n int) bool {
-	if n < 2 {
-		return false
-	}
-	for i := 2; i <= int(math.Sqrt(float64(n))); i++ {
-		if n%i == 0 {
-			return false
+	// Start the gRPC server alongside the HTTP API
+	var grpcSrv *grpc.Server
+	if cfg.GRPCPort != "off" {
+		var err error
+		if grpcSrv, err = startGRPCServer(cfg.GRPCPort); err != nil {
+			log.Fatal("Failed to start gRPC server:", err)
 		}
 	}
-	return true
-}
 
-// isPerfect checks if a number is a perfect number.
-func isPerfect(n int) bool {
-	if n <= 0 { // Ensure 0 and negative numbers are not considered perfect
-		return false
-	}
-	
-	sum := 0
-	for i := 1; i < n; i++ {
-		if n%i == 0 {
-			sum += i
+	// Serve HTTPS when a certificate is configured; HTTP/2 is negotiated automatically
+	useTLS := (cfg.TLSCertFile != "" && cfg.TLSKeyFile != "") || cfg.TLSCertDir != ""
+	if useTLS {
+		certFile, keyFile, err := resolveCertFiles(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSCertDir)
+		if err != nil {
+			log.Fatal("Failed to configure TLS:", err)
+		}
+		reloader, err := newCertReloader(certFile, keyFile)
+		if err != nil {
+			log.Fatal("Failed to load TLS certificate:", err)
+		}
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
 		}
-	}
-	return sum == n
-}
-
-// isArmstrong checks if a number is an Armstrong number.
-func isArmstrong(n int) bool {
-	sum := 0
-	temp := n
-	numDigits := len(strconv.Itoa(n))
-
-	for temp > 0 {
-		digit := temp % 10
-		sum += int(math.Pow(float64(digit), float64(numDigits)))
-		temp /= 10
 	}
 
-	return sum == n
-}
+	// Fetch the fun facts of known-popular numbers before anyone asks
+	startFunFactWarmer(cfg.FunFactWarmNumbers)
 
-// digitSum calculates the sum of digits of a number.
-func digitSum(n int) int {
-	n = int(math.Abs(float64(n))) // Ensure positive sum for negatives
-	sum := 0
-	for n != 0 {
-		sum += n % 10
-		n /= 10
-	}
-	return sum
-}
+	// Classify PRECOMPUTE_RANGE up front so those requests are served from memory
+	precomputeRange(cfg.PrecomputeRange)
 
-// getFunFact fetches a fun fact about the number using Numbers API.
-func getFunFact(n int) string {
-	url := fmt.Sprintf("http://numbersapi.com/%d/math?json", n)
-	resp, err := http.Get(url)
-	if err != nil {
-		return fmt.Sprintf("%d is an interesting number!", n) // Fallback fun fact
+	// Start the API server
+	go func() {
+		var err error
+		if useTLS {
+			log.Printf("Server running with TLS on port %s...", cfg.Port)
+			err = server.ListenAndServeTLS("", "") // Certificate comes from TLSConfig
+		} else {
+			log.Printf("Server running on port %s...", cfg.Port)
+			err = server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	// Wait for SIGTERM (rolling deploys) or Ctrl+C
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
+	<-stop
+
+	// Fail readiness first and give the load balancer time to notice
+	startDraining()
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	// Let in-flight requests finish before exiting
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Graceful shutdown timed out: %v", err)
 	}
-	defer resp.Body.Close()
-
-	var result map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return fmt.Sprintf("%d is an interesting number!", n) // Fallback fun fact
+	if grpcSrv != nil {
+		grpcSrv.GracefulStop()
 	}
-
-	if fact, exists := result["text"].(string); exists {
-		return fact
+	if err := stopTracing(ctx); err != nil {
+		log.Printf("Flushing spans failed: %v", err)
 	}
-
-	return fmt.Sprintf("%d is an interesting number!", n) // Final fallback
+	log.Println("Server stopped")
 }
 
-func main() {
-	// Initialize Gin router
-	r := gin.Default()
+// newRouter builds the HTTP API: the shared middleware, every endpoint
+// ENDPOINT_FLAGS enables, the probes and the JSON 404 and 405 handlers.
+func newRouter() *gin.Engine {
+	// Initialize Gin router, logging sanitized paths
+	r := gin.New()
+	r.Use(requestLogger(), gin.Recovery())
+
+	// Size and timing headers for ?debug=true requests
+	r.Use(debugHeaders())
 
 	// Enable CORS (Allow requests from anywhere)
 	r.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Content-Type", "application/json")
+		c.Writer.Header().Set("X-Content-Type-Options", "nosniff") // Never sniff echoed input as HTML
 		c.Next()
 	})
 
-	// Define API endpoint
-	r.GET("/api/classify-number", classifyNumber)
+	// Require an API key when any are configured (probes stay open)
+	r.Use(requireAPIKey())
 
-	// Get the PORT from environment variables (Render assigns a dynamic port)
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080" // Default port for local testing
-	}
+	// Cap request body sizes for any handler that reads the body
+	r.Use(limitBody(cfg.MaxBodyBytes))
 
-	// Start the API server
-	log.Printf("Server running on port %s...", port)
-	err := r.Run(":" + port)
-	if err != nil {
-		log.Fatal("Failed to start server:", err)
+	// Reject repeated scalar query params instead of silently using the first
+	r.Use(rejectDuplicateParams())
+
+	// Define API endpoints, unversioned (latest) and pinned to each version,
+	// each request in a trace span. Their in-flight count decides when
+	// classifications go lite; probes and long-lived WebSockets don't count.
+	registerAPIRoutes(r.Group("/api", traceRequests(), shedLoad(), apiVersion(0)))
+	registerAPIRoutes(r.Group("/api/v1", traceRequests(), shedLoad(), apiVersion(1)))
+	checkEndpointFlags(cfg.EndpointFlags)
+
+	// Interactive classification over a WebSocket
+	r.GET("/ws/classify", classifyWebSocket)
+
+	// Health probes for the load balancer / Kubernetes
+	r.GET("/healthz", healthz)
+	r.GET("/readyz", readyz)
+
+	// Expose Prometheus metrics
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Profiling endpoints, off unless explicitly enabled
+	if cfg.PprofEnabled {
+		mountPprof(r)
 	}
+
+	// Describe the API at the root, and answer unknown paths and methods in JSON
+	r.GET("/", apiIndex(r))
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(notFound)
+	r.NoMethod(methodNotAllowed)
+	return r
+}
+
+// registerAPIRoutes mounts the API endpoints ENDPOINT_FLAGS enables on a
+// versioned group.
+func registerAPIRoutes(group *gin.RouterGroup) {
+	api := flaggedRoutes{group}
+	api.GET("/classify-number", allowJSONP(), classifyNumber)
+	api.GET("/nearest", nearestNumber)
+	api.GET("/scan", scanUpward)
+	api.GET("/random", allowJSONP(), randomNumber)
+	api.GET("/primes", primesInRange)
+	api.GET("/prime-count", primeCountUpTo)
+	api.GET("/sum-of-two-squares", sumOfTwoSquares)
+	api.GET("/sum-of-two-cubes", sumOfTwoCubes)
+	api.GET("/approximate", approximate)
+	api.GET("/compare", compareNumbers)
+	api.GET("/modclass", modClass)
+	api.GET("/cyclic", cyclicNumber)
+	api.GET("/guess-base", guessBase)
+	api.GET("/digital-root", digitalRoot)
+	api.GET("/classify-expr", classifyExpression)
+	api.GET("/untouchable", untouchable)
+	api.GET("/vampire", vampire)
+	api.GET("/classify-date", classifyDate)
+	api.GET("/classify-gaussian", classifyGaussian)
+	api.GET("/palindromes", palindromesInRange)
+	api.GET("/fib", fibonacciTerm)
+	api.GET("/fib-index", fibonacciIndex)
+	api.GET("/pisano", pisano)
+	api.GET("/primorial", primorial)
+	api.GET("/highly-composite", highlyComposite)
+	api.GET("/sequence", sequenceOf)
+	api.GET("/range-properties", propertiesInRange)
+	api.GET("/list/:property", listSparse)
+	api.GET("/capabilities", getCapabilities)
+	api.GET("/stats", classificationStatsHandler)
+	api.POST("/jobs", createJob)
+	api.GET("/jobs/:id", getJob)
+	api.GET("/jobs/:id/callback-status", getCallbackStatus)
+	api.GET("/jobs/:id/export", exportJob)
+	api.POST("/filter", filterNumbers)
 }
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testRouter is the full API, built once since routes register globally.
+var testRouter *gin.Engine
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	log.SetOutput(io.Discard)
+	cfg.FunFactMode = funFactStatic // Never call the Numbers API from tests
+	testRouter = newRouter()
+	os.Exit(m.Run())
+}
+
+// get serves a GET request for target through the full router.
+func get(t *testing.T, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// classifiedMagnitude records the order of magnitude of classified numbers.
+// Buckets are powers of ten, so exact values are never exported.
+var classifiedMagnitude = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "numclass_classified_number_magnitude",
+	Help:    "Absolute value of classified numbers, bucketed by power of ten.",
+	Buckets: prometheus.ExponentialBuckets(1, 10, 19), // 1 .. 1e18
+})
+
+// funFactInFlight is the number of outbound fun-fact requests in progress.
+var funFactInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "numclass_fun_fact_requests_in_flight",
+	Help: "Outbound Numbers API requests currently in progress.",
+})
+
+// funFactSaturated counts fun facts that fell back because every slot was busy.
+var funFactSaturated = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "numclass_fun_fact_saturated_total",
+	Help: "Fun facts served from the fallback template because the outbound limit was reached.",
+})
+
+// funFactUpstreamDuration times each Numbers API request, from sending it to
+// decoding the body, labeled by how it ended.
+var funFactUpstreamDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "numclass_fun_fact_upstream_duration_seconds",
+	Help:    "Latency of outbound Numbers API requests, by outcome.",
+	Buckets: []float64{0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 5},
+}, []string{"outcome"})
+
+// funFactUpstreamRequests counts Numbers API requests by outcome: ok,
+// timeout, error, bad_status or decode_error.
+var funFactUpstreamRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "numclass_fun_fact_upstream_requests_total",
+	Help: "Outbound Numbers API requests, by outcome.",
+}, []string{"outcome"})
+
+// funFactConnections counts the connections Numbers API requests went out
+// on, and whether each was an idle keep-alive connection from the pool.
+var funFactConnections = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "numclass_fun_fact_connections_total",
+	Help: "Connections used for Numbers API requests, by whether they were reused from the idle pool.",
+}, []string{"reused"})
+
+// classificationsCoalesced counts classification requests whose result was
+// computed once for several identical concurrent requests.
+var classificationsCoalesced = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "numclass_classifications_coalesced_total",
+	Help: "Classification requests answered by a computation shared with identical concurrent requests.",
+})
+
+// degradedRequests counts classifications served lite because too many
+// requests were in flight.
+var degradedRequests = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "numclass_degraded_requests_total",
+	Help: "Classifications served with only the degraded field set because DEGRADE_THRESHOLD was exceeded.",
+})
+
+// webhookDeadLettered counts job callbacks abandoned after every attempt failed.
+var webhookDeadLettered = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "numclass_webhook_dead_lettered_total",
+	Help: "Job callbacks given up on after the maximum number of delivery attempts.",
+})
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// repeatableParams lists query params that may appear more than once. Every
+// other param is scalar, and repeating it is rejected rather than silently
+// using the first value (?number=3&number=foo would otherwise classify 3).
+var repeatableParams = map[string]bool{}
+
+// rejectDuplicateParams returns 400 when a scalar query param is repeated.
+func rejectDuplicateParams() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+		keys := make([]string, 0, len(query))
+		for key := range query {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys) // Report the same param on every run
+
+		for _, key := range keys {
+			if values := query[key]; len(values) > 1 && !repeatableParams[key] {
+				render(c, http.StatusBadRequest, gin.H{
+					"param":   sanitizeEcho(key),
+					"values":  sanitizeValues(values),
+					"error":   true,
+					"message": "query param must not be repeated",
+				})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// modClassification is the body of GET /api/modclass.
+type modClassification struct {
+	Number             int  `json:"number"`
+	Mod                int  `json:"mod"`
+	Residue            int  `json:"residue"` // number mod mod, always in [0, mod)
+	GCD                int  `json:"gcd"`
+	IsQuadraticResidue bool `json:"is_quadratic_residue"` // x² ≡ number (mod mod) for some x, 0 included
+	Inverse            *int `json:"inverse"`              // x in [0, mod) with number·x ≡ 1, null unless gcd is 1
+}
+
+// modClass reports where a number falls modulo mod: its residue, whether it
+// is a square there, and its inverse.
+func modClass(c *gin.Context) {
+	number, ok := numberQuery(c)
+	if !ok {
+		return
+	}
+	mod, ok := numberParam(c, "mod")
+	if !ok {
+		return
+	}
+	if mod < 1 || mod > cfg.ModClassMaxMod {
+		respondError(c, http.StatusBadRequest, c.Query("mod"), fmt.Sprintf("mod must be between 1 and %d", cfg.ModClassMaxMod))
+		return
+	}
+
+	residue := floorMod(number, mod)
+	result := modClassification{
+		Number:             number,
+		Mod:                mod,
+		Residue:            residue,
+		GCD:                gcd(residue, mod),
+		IsQuadraticResidue: isQuadraticResidue(residue, mod),
+	}
+	if inverse := new(big.Int).ModInverse(big.NewInt(int64(residue)), big.NewInt(int64(mod))); inverse != nil {
+		x := int(inverse.Int64())
+		result.Inverse = &x
+	} else if mod == 1 {
+		zero := 0 // Everything is 0 mod 1, and 0 × 0 ≡ 1 there
+		result.Inverse = &zero
+	}
+	render(c, http.StatusOK, result)
+}
+
+// floorMod is n mod m in [0, m) for m > 0, so -1 mod 7 is 6 where Go's %
+// gives -1.
+func floorMod(n, m int) int {
+	r := n % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// isQuadraticResidue checks if x² ≡ r (mod m) has a solution, for 0 <= r < m.
+// By the Chinese remainder theorem that holds exactly when it does modulo
+// each prime power p^k of m. There, r = p^j·u with u coprime to p must have
+// j >= k, or j even with u a square mod p^(k-j): by Euler's criterion for
+// odd p, and u ≡ 1 mod 8 (mod 4 below 2^3, anything mod 2) for p = 2.
+func isQuadraticResidue(r, m int) bool {
+	for _, f := range factorize(m) {
+		pk := 1
+		for i := 0; i < f.Exponent; i++ {
+			pk *= f.Prime
+		}
+		u, j := r%pk, 0
+		if u == 0 {
+			continue // j >= k: 0 is 0²
+		}
+		for u%f.Prime == 0 {
+			u, j = u/f.Prime, j+1
+		}
+		if j%2 != 0 {
+			return false
+		}
+		e := f.Exponent - j
+		switch {
+		case f.Prime != 2:
+			if powMod(uint64(u%f.Prime), uint64(f.Prime-1)/2, uint64(f.Prime)) != 1 {
+				return false
+			}
+		case e == 2 && u%4 != 1, e >= 3 && u%8 != 1:
+			return false
+		}
+	}
+	return true
+}
+
+// powMod returns b^e mod m by square-and-multiply.
+func powMod(b, e, m uint64) uint64 {
+	result, b := 1%m, b%m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMod(result, b, m)
+		}
+		b = mulMod(b, b, m)
+	}
+	return result
+}
+
+// mulMod returns a·b mod m through the full 128-bit product, so it can't
+// overflow for any 64-bit m.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
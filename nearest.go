@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// nearestResult is the body of GET /api/nearest.
+type nearestResult struct {
+	Number      int    `json:"number"`
+	Property    string `json:"property"`
+	Below       *int   `json:"below"` // null when nothing was found within max_distance
+	Above       *int   `json:"above"`
+	MaxDistance int    `json:"max_distance"`
+}
+
+// nearestNumber finds the closest numbers below and above the input that have
+// the requested property, searching at most cfg.NearestMaxDistance steps each
+// way. Sparse properties with a complete list are looked up without a bound;
+// factored ones only search from numbers up to SEARCH_MAX_NUMBER.
+func nearestNumber(c *gin.Context) {
+	number, ok := numberQuery(c)
+	if !ok {
+		return
+	}
+
+	name, check, ok := resolveProperty(c, c.Query("property"))
+	if !ok {
+		return
+	}
+
+	var below, above *int
+	if members, ok := sparseMembers[name]; ok {
+		below, above = nearestMember(members, number)
+	} else {
+		if !checkSearchBound(c, name, number) {
+			return
+		}
+		ctx, cancel := searchContext(c)
+		defer cancel()
+		var err error
+		if below, above, err = searchNearest(ctx, number, check, cfg.NearestMaxDistance); err != nil {
+			respondSearchStopped(c, err)
+			return
+		}
+	}
+
+	render(c, http.StatusOK, nearestResult{
+		Number:      number,
+		Property:    name,
+		Below:       below,
+		Above:       above,
+		MaxDistance: cfg.NearestMaxDistance,
+	})
+}
+
+// searchNearest walks outward from n and returns the first matches strictly
+// below and above it, or nil for a side with no match within maxDistance.
+// It gives up with ctx's error once ctx is done.
+func searchNearest(ctx context.Context, n int, check func(int) bool, maxDistance int) (below, above *int, err error) {
+	for d := 1; d <= maxDistance && (below == nil || above == nil); d++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		if below == nil && n >= math.MinInt+d && check(n-d) {
+			v := n - d
+			below = &v
+		}
+		if above == nil && n <= math.MaxInt-d && check(n+d) {
+			v := n + d
+			above = &v
+		}
+	}
+	return below, above, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNearestSearchBound(t *testing.T) {
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{"number=1000000000000000000&property=carmichael", http.StatusBadRequest},
+		{"number=-1000000000000000000&property=prime", http.StatusBadRequest},
+		{"number=1000000000000&property=prime", http.StatusOK},
+		{"number=1000000000000000000&property=perfect", http.StatusOK},    // A list lookup
+		{"number=1000000000000000000&property=palindrome", http.StatusOK}, // A digit check
+	}
+	for _, tt := range tests {
+		start := time.Now()
+		w := get(t, "/api/nearest?"+tt.query)
+		if w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.query, w.Code, tt.status, w.Body)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("%s took %v", tt.query, elapsed)
+		}
+	}
+}
+
+func TestNearestSearchTimeout(t *testing.T) {
+	defer func(d time.Duration) { cfg.SearchTimeout = d }(cfg.SearchTimeout)
+	cfg.SearchTimeout = time.Nanosecond
+
+	w := get(t, "/api/nearest?number=100&property=prime")
+	var body struct{ Message string }
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(body.Message, "did not finish") {
+		t.Errorf("status %d, message %q, want 503 for the timeout", w.Code, body.Message)
+	}
+}
+
+func TestSearchNearestCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	checks := 0
+	_, _, err := searchNearest(ctx, 100, func(int) bool { checks++; return false }, 10)
+	if err != context.Canceled || checks != 0 {
+		t.Errorf("err %v after %d checks, want context.Canceled before any", err, checks)
+	}
+}
@@ -0,0 +1,702 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/adidazbot/num_class_api/numclass"
+)
+
+// isPrime checks if a number is prime.
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for i, limit := 2, isqrt(n); i <= limit; i++ {
+		if n%i == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isPerfect checks if a number is a perfect number, using the precomputed
+// list instead of summing divisors.
+func isPerfect(n int) bool {
+	return isSparseMember(perfectNumbers, n)
+}
+
+// aliquotSum returns the sum of the proper divisors of a positive number,
+// σ(n) - n. ok is false when the sum doesn't fit in an int, as for the
+// highly composite numbers near 2^63 whose divisors add up to 4n and more.
+func aliquotSum(n int) (sum int, ok bool) {
+	return aliquotSumOf(n, lazyFactors(n))
+}
+
+// aliquotSumOf is aliquotSum with n's factorization supplied by factorsOf.
+func aliquotSumOf(n int, factorsOf func() []primeFactor) (sum int, ok bool) {
+	if n <= 1 {
+		return 0, true
+	}
+	s := bigSigma(factorsOf())
+	s.Sub(s, big.NewInt(int64(n)))
+	if !s.IsInt64() {
+		return 0, false
+	}
+	return int(s.Int64()), true
+}
+
+// bigSigma returns σ(n), the sum of all divisors, from n's factorization.
+// It can pass 64 bits, so it is built with math/big.
+func bigSigma(factors []primeFactor) *big.Int {
+	sigma := big.NewInt(1)
+	for _, f := range factors {
+		sigma.Mul(sigma, new(big.Int).SetUint64(primePowerSigma(f)))
+	}
+	return sigma
+}
+
+// aliquotSumsUpTo returns s where s[m] is aliquotSum(m) for 0 <= m <= limit,
+// sieving every divisor into its multiples instead of factoring each m.
+func aliquotSumsUpTo(limit int) []int {
+	sums := make([]int, limit+1)
+	for d := 1; d <= limit/2; d++ {
+		for m := 2 * d; m <= limit; m += d {
+			sums[m] += d
+		}
+	}
+	return sums
+}
+
+// isArmstrong checks if a number is an Armstrong number by looking it up in
+// the complete list. Like the other digit-based checks it looks at the
+// magnitude, so -153 qualifies.
+func isArmstrong(n int) bool {
+	return isSparseMember(sparseMembers["armstrong"], n)
+}
+
+// isDisarium checks if the digits of |n|, each raised to the power of its
+// position from the left, sum to |n| (175 = 1¹ + 7² + 5³). Powers are taken
+// by repeated multiplication, not math.Pow, so they stay exact; even 19
+// nines sum to under 2^61, so the total can't overflow.
+func isDisarium(n int) bool {
+	m := magnitude(n)
+	return disariumSum(strconv.FormatUint(m, 10)) == m
+}
+
+// disariumSum adds each decimal digit raised to its 1-based position.
+func disariumSum(digits string) uint64 {
+	var sum uint64
+	for i := range digits {
+		d, term := uint64(digits[i]-'0'), uint64(1)
+		for p := 0; p <= i; p++ {
+			term *= d
+		}
+		sum += term
+	}
+	return sum
+}
+
+// isBigDisarium is isDisarium for the decimal digits of a number beyond 64
+// bits. From 23 digits on, even all nines sum to fewer digits than the
+// number has, so only shorter ones are summed.
+func isBigDisarium(digits string, abs *big.Int) bool {
+	if len(digits) >= 23 {
+		return false
+	}
+	sum, term := new(big.Int), new(big.Int)
+	for i := range digits {
+		sum.Add(sum, term.Exp(big.NewInt(int64(digits[i]-'0')), big.NewInt(int64(i+1)), nil))
+	}
+	return sum.Cmp(abs) == 0
+}
+
+// isBigArmstrong is isArmstrong for the decimal digits of a number beyond 64
+// bits, summing each digit raised to the digit count exactly. From 61 digits
+// on, even all nines sum to fewer digits than the number has.
+func isBigArmstrong(digits string, abs *big.Int) bool {
+	if len(digits) > 60 {
+		return false
+	}
+	k := big.NewInt(int64(len(digits)))
+	sum, term := new(big.Int), new(big.Int)
+	for i := range digits {
+		sum.Add(sum, term.Exp(big.NewInt(int64(digits[i]-'0')), k, nil))
+	}
+	return sum.Cmp(abs) == 0
+}
+
+// Digit economy classes.
+const (
+	economyFrugal      = "frugal"      // Fewer digits in the factorization than in n
+	economyEquidigital = "equidigital" // As many
+	economyExtravagant = "extravagant" // More
+)
+
+// digitEconomy compares the decimal digits of n >= 1 with those needed to
+// write its prime factorization, counting every prime and each exponent
+// above 1: 125 = 5^3 takes 2 digits, so it is frugal, 10 = 2 × 5 takes 2,
+// equidigital, and 4 = 2^2 takes 2, extravagant. 1 has no prime factors and
+// counts as equidigital, as in OEIS A046758.
+func digitEconomy(n int) (string, bool) {
+	return digitEconomyOf(n, lazyFactors(n))
+}
+
+// digitEconomyOf is digitEconomy with n's factorization supplied by factorsOf.
+func digitEconomyOf(n int, factorsOf func() []primeFactor) (string, bool) {
+	switch {
+	case n < 1:
+		return "", false
+	case n == 1:
+		return economyEquidigital, true
+	}
+	factorDigits := 0
+	for _, f := range factorsOf() {
+		factorDigits += len(strconv.Itoa(f.Prime))
+		if f.Exponent > 1 {
+			factorDigits += len(strconv.Itoa(f.Exponent))
+		}
+	}
+	switch digits := len(strconv.Itoa(n)); {
+	case factorDigits < digits:
+		return economyFrugal, true
+	case factorDigits == digits:
+		return economyEquidigital, true
+	}
+	return economyExtravagant, true
+}
+
+// isPalindrome checks if a number's decimal digits read the same both ways.
+func isPalindrome(n int) bool {
+	if n < 0 {
+		n = -n // Compare digits of the magnitude
+	}
+	s := strconv.Itoa(n)
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		if s[i] != s[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// isUndulating checks if the digits of |n| alternate between two distinct
+// values, a-b-a-b... (121, 5454, 171717). One- and two-digit numbers are not
+// undulating: they alternate only trivially.
+func isUndulating(n int) bool {
+	return undulating(strconv.FormatUint(magnitude(n), 10))
+}
+
+// undulating checks a decimal digit string for the a-b-a-b... pattern.
+func undulating(s string) bool {
+	if len(s) < 3 || s[0] == s[1] {
+		return false
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] != s[i-2] {
+			return false
+		}
+	}
+	return true
+}
+
+// isCircularPrime checks if every rotation of n's digits is prime (197, 971,
+// 719). Single-digit primes count. A rotation with a leading zero is read as
+// the shorter number, but a multi-digit number with any digit but 1, 3, 7 or
+// 9 has a rotation ending in it, which is even or a multiple of 5.
+func isCircularPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	digits := strconv.Itoa(n)
+	if len(digits) > 1 && strings.Trim(digits, "1379") != "" {
+		return false
+	}
+	for _, rotation := range digitRotations(digits) {
+		if !isPrimeDigits(rotation) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrimorial checks if n is the product of the first k primes for some k
+// (1, 2, 6, 30, 210, ...), counting 0# = 1 as the empty product.
+func isPrimorial(n int) bool {
+	return isSparseMember(primorialNumbers, n)
+}
+
+// isHighlyComposite checks if n has more divisors than any smaller positive
+// integer (1, 2, 4, 6, 12, 24, 36, ...).
+func isHighlyComposite(n int) bool {
+	return isSparseMember(highlyCompositeNumbers, n)
+}
+
+// isPrimeDigits checks a rotation of an int's digits for primality. The
+// rotation may exceed the int range, though never 64 bits.
+func isPrimeDigits(digits string) bool {
+	v, _ := strconv.ParseUint(digits, 10, 64)
+	if v > math.MaxInt {
+		return new(big.Int).SetUint64(v).ProbablyPrime(20)
+	}
+	return isPrime(int(v))
+}
+
+// digitSum calculates the sum of digits of a number's magnitude.
+func digitSum(n int) int {
+	return int(digitSumInBase(magnitude(n), 10))
+}
+
+// digitSumInBase adds up the digits of n written in the given base.
+func digitSumInBase(n, base uint64) uint64 {
+	sum := uint64(0)
+	for ; n != 0; n /= base {
+		sum += n % base
+	}
+	return sum
+}
+
+// digitCounts returns how often each digit appears in n written in base.
+func digitCounts(n, base uint64) []int {
+	counts := make([]int, base)
+	if n == 0 {
+		counts[0] = 1
+	}
+	for ; n != 0; n /= base {
+		counts[n%base]++
+	}
+	return counts
+}
+
+// isPandigital checks if |n| written in base uses every digit of that base
+// at least once (1023456789 in base 10). Zeroless pandigitals only need the
+// digits 1 to base-1 (123456789).
+func isPandigital(n, base int, zeroless bool) bool {
+	counts := digitCounts(magnitude(n), uint64(base))
+	for d, count := range counts {
+		if count == 0 && (d > 0 || !zeroless) {
+			return false
+		}
+	}
+	return true
+}
+
+// primeFactor is a prime and its exponent in a factorization.
+type primeFactor = numclass.PrimeFactor
+
+// factorize returns the prime factorization of n >= 2 in ascending prime order.
+func factorize(n int) []primeFactor {
+	factors := []primeFactor{}
+	if n < 2 {
+		return factors
+	}
+	for p := 2; p <= n/p; p++ {
+		if n%p != 0 {
+			continue
+		}
+		exp := 0
+		for n%p == 0 {
+			n /= p
+			exp++
+		}
+		factors = append(factors, primeFactor{Prime: p, Exponent: exp})
+	}
+	if n > 1 {
+		factors = append(factors, primeFactor{Prime: n, Exponent: 1}) // Leftover prime
+	}
+	return factors
+}
+
+// lazyFactors returns a function that factorizes n on its first call and
+// returns the same factors after that. classify shares one across every
+// check, since near 2^63 each factorization is seconds of trial division,
+// and a check that rejects n without its factors never pays for them.
+func lazyFactors(n int) func() []primeFactor {
+	var factors []primeFactor
+	return func() []primeFactor {
+		if factors == nil {
+			factors = factorize(n)
+		}
+		return factors
+	}
+}
+
+// isPractical checks if every smaller positive integer is a sum of distinct
+// divisors of n. Uses Stewart's criterion: with primes p1 < p2 < ..., each
+// p_i must be at most 1 + σ(p1^e1 ... p_{i-1}^e_{i-1}).
+func isPractical(n int) bool {
+	return practical(n, lazyFactors(n))
+}
+
+// practical is isPractical with n's factorization supplied by factorsOf.
+func practical(n int, factorsOf func() []primeFactor) bool {
+	if n == 1 {
+		return true
+	}
+	if n < 1 || n%2 != 0 { // Every practical number above 1 is even
+		return false
+	}
+
+	sigma := 1 // σ of the product of the prime powers seen so far
+	for _, f := range factorsOf() {
+		if f.Prime > sigma+1 {
+			return false
+		}
+		// σ(p^e) = 1 + p + ... + p^e, multiplicative over coprime factors
+		term, power := 1, 1
+		for i := 0; i < f.Exponent; i++ {
+			power *= f.Prime
+			term += power
+		}
+		sigma *= term
+	}
+	return true
+}
+
+// isCarmichael checks Korselt's criterion: n is composite and squarefree, and
+// p-1 divides n-1 for every prime p dividing n. These are the composites
+// that pass the Fermat test for every coprime base (561, 1105, 1729, ...).
+func isCarmichael(n int) bool {
+	return carmichael(n, lazyFactors(n))
+}
+
+// carmichael is isCarmichael with n's factorization supplied by factorsOf.
+func carmichael(n int, factorsOf func() []primeFactor) bool {
+	if n < 561 || n%2 == 0 { // 561 is the smallest, and all of them are odd
+		return false
+	}
+	factors := factorsOf()
+	if len(factors) < 3 { // Carmichael numbers have at least three prime factors
+		return false
+	}
+	for _, f := range factors {
+		if f.Exponent > 1 || (n-1)%(f.Prime-1) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isSphenic checks if n is the product of exactly three distinct primes
+// (30 = 2·3·5, 42, 66, ...).
+func isSphenic(n int) bool {
+	return sphenic(n, lazyFactors(n))
+}
+
+// sphenic is isSphenic with n's factorization supplied by factorsOf.
+func sphenic(n int, factorsOf func() []primeFactor) bool {
+	if n < 30 { // 2·3·5 is the smallest
+		return false
+	}
+	factors := factorsOf()
+	if len(factors) != 3 {
+		return false
+	}
+	for _, f := range factors {
+		if f.Exponent > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// isPowerful checks if every prime dividing n divides it at least twice
+// (1, 4, 8, 9, 16, 25, 27, 32, 36, ...).
+func isPowerful(n int) bool {
+	if n < 1 {
+		return false
+	}
+	for _, f := range factorize(n) {
+		if f.Exponent < 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// isPerfectPower checks if n is m^k for whole numbers m and k >= 2 (1, 4, 8,
+// 9, 16, ...). From the factorization: exactly when the exponents share a
+// common factor k >= 2, since n is then (∏ p^(e/k))^k.
+func isPerfectPower(n int) bool {
+	if n == 1 {
+		return true
+	}
+	return n > 1 && exponentGCD(factorize(n)) >= 2
+}
+
+// exponentGCD returns the gcd of the exponents in a factorization.
+func exponentGCD(factors []primeFactor) int {
+	g := 0
+	for _, f := range factors {
+		g = gcd(g, f.Exponent)
+	}
+	return g
+}
+
+// isAchilles checks if n is powerful but not a perfect power (72 = 2³·3²,
+// 108, 200, ...): every exponent is at least 2, and they share no common factor.
+func isAchilles(n int) bool {
+	return achilles(n, lazyFactors(n))
+}
+
+// achilles is isAchilles with n's factorization supplied by factorsOf.
+func achilles(n int, factorsOf func() []primeFactor) bool {
+	if n < 72 { // 72 is the smallest
+		return false
+	}
+	factors := factorsOf()
+	for _, f := range factors {
+		if f.Exponent < 2 {
+			return false
+		}
+	}
+	return exponentGCD(factors) == 1
+}
+
+// isDuffinian checks if n is composite and coprime to σ(n), the sum of its
+// divisors (35: σ = 48; 49, 77, ...). σ(n) is the product of σ(p^e) over the
+// factorization, so n only has to share no factor with each of those, which
+// fit in 64 bits even when σ(n) does not.
+func isDuffinian(n int) bool {
+	return duffinian(n, lazyFactors(n))
+}
+
+// duffinian is isDuffinian with n's factorization supplied by factorsOf.
+func duffinian(n int, factorsOf func() []primeFactor) bool {
+	if n < 4 {
+		return false
+	}
+	factors := factorsOf()
+	if len(factors) == 1 && factors[0].Exponent == 1 {
+		return false // Prime
+	}
+	for _, f := range factors {
+		if gcd(n, int(primePowerSigma(f)%uint64(n))) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// isKeith checks if n is a Keith number: n has k >= 2 digits and appears in
+// the sequence that starts with those digits, each later term being the sum
+// of the k before it (197: 1, 9, 7, 17, 33, 57, 107, 197). Single digits
+// trivially start their own sequence, so they are excluded, as in OEIS A007629.
+func isKeith(n int) bool {
+	if n < 10 {
+		return false
+	}
+	terms, overflowed := keithTerms(n)
+	return !overflowed && terms[len(terms)-1] == n
+}
+
+// keithTerms returns n's Keith sequence up to the first term >= n. When the
+// next term would overflow it stops short and reports overflowed instead: that
+// term is past MaxInt, so the sequence skips n.
+func keithTerms(n int) (terms []int, overflowed bool) {
+	digits := strconv.Itoa(n)
+	terms = make([]int, len(digits))
+	for i := range digits {
+		terms[i] = int(digits[i] - '0')
+	}
+	for terms[len(terms)-1] < n {
+		next := 0
+		for _, t := range terms[len(terms)-len(digits):] {
+			if next > math.MaxInt-t {
+				return terms, true
+			}
+			next += t
+		}
+		terms = append(terms, next)
+	}
+	return terms, false
+}
+
+// primePowerSigma returns σ(p^e) = 1 + p + ... + p^e, which is below 2·p^e.
+func primePowerSigma(f primeFactor) uint64 {
+	sum, power := uint64(1), uint64(1)
+	for i := 0; i < f.Exponent; i++ {
+		power *= uint64(f.Prime)
+		sum += power
+	}
+	return sum
+}
+
+// isHoax checks if n is a hoax number: a composite whose digit sum equals
+// the digit sums of its distinct prime factors added up (22 = 2 × 11:
+// 2+2 = 2 + 1+1). Unlike Smith numbers, a repeated factor counts once, so
+// 84 = 2² × 3 × 7 is a hoax number (12 = 2 + 3 + 7) but not a Smith number.
+func isHoax(n int) bool {
+	return hoax(n, lazyFactors(n))
+}
+
+// hoax is isHoax with n's factorization supplied by factorsOf.
+func hoax(n int, factorsOf func() []primeFactor) bool {
+	if n < 4 {
+		return false
+	}
+	factors := factorsOf()
+	if len(factors) == 1 && factors[0].Exponent == 1 {
+		return false // Prime
+	}
+	return distinctFactorDigitSum(factors) == digitSum(n)
+}
+
+// distinctFactorDigitSum adds up the digit sums of each distinct prime.
+func distinctFactorDigitSum(factors []primeFactor) int {
+	sum := 0
+	for _, f := range factors {
+		sum += digitSum(f.Prime)
+	}
+	return sum
+}
+
+// isSelfNumber checks that n is not m + digitSum(m) for any m (1, 3, 5, 7, 9,
+// 20, 31, ...). A generator m is at most 9 per digit below n, so only that
+// short window is searched.
+func isSelfNumber(n int) bool {
+	_, found := selfGenerator(n)
+	return n >= 1 && !found
+}
+
+// selfGenerator finds an m with m + digitSum(m) = n, if there is one.
+func selfGenerator(n int) (int, bool) {
+	window := 9 * len(strconv.Itoa(n))
+	for m := max(0, n-window); m < n; m++ {
+		if m+digitSum(m) == n {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// reverseDigits reverses the decimal digits of n's magnitude and keeps its
+// sign, dropping leading zeros (1200 -> 21, -53 -> -35). ok is false when the
+// reversed value does not fit in an int.
+func reverseDigits(n int) (reversed int, ok bool) {
+	sign := 1
+	if n < 0 {
+		sign = -1
+	}
+	for n != 0 {
+		digit := sign * (n % 10) // Non-negative digit of the magnitude
+		if reversed > (math.MaxInt-digit)/10 {
+			return 0, false
+		}
+		reversed = reversed*10 + digit
+		n /= 10
+	}
+	return sign * reversed, true
+}
+
+// gcd returns the greatest common divisor of a and b (always non-negative).
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// lcm returns the least common multiple of a and b, or 0 if either is 0.
+func lcm(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	l := a / gcd(a, b) * b
+	if l < 0 {
+		return -l
+	}
+	return l
+}
+
+// divides reports whether d divides n (0 divides only 0).
+func divides(d, n int) bool {
+	if d == 0 {
+		return n == 0
+	}
+	return n%d == 0
+}
+
+// isPowerOfTwo checks if n is 2^k for some k >= 0.
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// isPowerOf checks if n is base^k for some k >= 0 (so 1 is a power of every
+// base). base must be at least 2.
+func isPowerOf(n, base int) bool {
+	if n < 1 {
+		return false
+	}
+	for n%base == 0 {
+		n /= base
+	}
+	return n == 1
+}
+
+// triangularIndex returns k such that k(k+1)/2 = n, if n is triangular.
+// n is triangular exactly when 8n+1 is a perfect square; big.Int keeps that
+// exact for n close to math.MaxInt.
+func triangularIndex(n int) (int, bool) {
+	if n < 0 {
+		return 0, false
+	}
+	disc := new(big.Int).SetInt64(int64(n))
+	disc.Mul(disc, big.NewInt(8)).Add(disc, big.NewInt(1))
+	root := new(big.Int).Sqrt(disc)
+	if new(big.Int).Mul(root, root).Cmp(disc) != 0 {
+		return 0, false
+	}
+	return int((root.Int64() - 1) / 2), true
+}
+
+// squareIndex returns k such that k² = n, if n is a perfect square.
+func squareIndex(n int) (int, bool) {
+	if n < 0 {
+		return 0, false
+	}
+	k := isqrt(n)
+	return k, k*k == n
+}
+
+// magnitude returns |n| as an unsigned value, which is exact even for math.MinInt.
+func magnitude(n int) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
+
+// isEvil checks if the binary form of |n| has an even number of 1s (0 is evil).
+func isEvil(n int) bool {
+	return bits.OnesCount64(magnitude(n))%2 == 0
+}
+
+// isOdious checks if the binary form of |n| has an odd number of 1s.
+func isOdious(n int) bool {
+	return !isEvil(n)
+}
+
+// isBinaryPalindrome checks if the binary form of |n| reads the same both
+// ways (5 = 101, 9 = 1001, but not 6 = 110), by reversing its bits and
+// dropping the leading zeros the reversal brings in. 0 is a single 0 bit.
+func isBinaryPalindrome(n int) bool {
+	m := magnitude(n)
+	return bits.Reverse64(m)>>(64-bits.Len64(m)) == m // A shift by 64 leaves 0 for 0
+}
+
+// isBigBinaryPalindrome is isBinaryPalindrome for a magnitude beyond 64 bits.
+func isBigBinaryPalindrome(abs *big.Int) bool {
+	for i, j := 0, abs.BitLen()-1; i < j; i, j = i+1, j-1 {
+		if abs.Bit(i) != abs.Bit(j) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,494 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// predicateTest is one input to a bool check and the answer it should give.
+type predicateTest struct {
+	n    int
+	want bool
+}
+
+// testPredicate runs check over a table of predicateTests.
+func testPredicate(t *testing.T, name string, check func(int) bool, tests []predicateTest) {
+	t.Helper()
+	for _, tt := range tests {
+		if got := check(tt.n); got != tt.want {
+			t.Errorf("%s(%d) = %v, want %v", name, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestAliquotSum(t *testing.T) {
+	tests := []struct{ n, want int }{
+		{1, 0},
+		{7, 1},
+		{6, 6},
+		{12, 16},
+		{28, 28},
+	}
+	for _, tt := range tests {
+		if got, ok := aliquotSum(tt.n); !ok || got != tt.want {
+			t.Errorf("aliquotSum(%d) = %d, %v, want %d", tt.n, got, ok, tt.want)
+		}
+	}
+	// The divisors of the largest highly composite int sum past MaxInt
+	n := highlyCompositeNumbers[len(highlyCompositeNumbers)-1]
+	if _, ok := aliquotSum(n); ok {
+		t.Errorf("aliquotSum(%d) fit in an int", n)
+	}
+}
+
+func TestIsPractical(t *testing.T) {
+	testPredicate(t, "isPractical", isPractical, []predicateTest{
+		{1, true},
+		{2, true},
+		{4, true},
+		{6, true},
+		{12, true},
+		{3, false},
+		{5, false},
+		{10, false}, // 4 is not 1, 2, 5 or a sum of them
+		{0, false},
+		{-12, false},
+	})
+}
+
+func TestIsCarmichael(t *testing.T) {
+	testPredicate(t, "isCarmichael", isCarmichael, []predicateTest{
+		{561, true},
+		{1105, true},
+		{1729, true},
+		{9, false}, // Composite, but not squarefree
+		{15, false},
+		{560, false},
+		{563, false}, // Prime
+		{1, false},
+	})
+}
+
+func TestIsSphenic(t *testing.T) {
+	testPredicate(t, "isSphenic", isSphenic, []predicateTest{
+		{30, true},
+		{42, true},
+		{66, true},
+		{105, true},
+		{60, false},  // 2 repeats
+		{210, false}, // Four primes
+		{29, false},
+		{7, false},
+	})
+}
+
+func TestIsAchilles(t *testing.T) {
+	testPredicate(t, "isAchilles", isAchilles, []predicateTest{
+		{72, true},
+		{108, true},
+		{200, true},
+		{288, true},
+		{36, false}, // Powerful, but 6²
+		{12, false}, // Not powerful
+		{64, false},
+		{71, false},
+	})
+}
+
+func TestIsDuffinian(t *testing.T) {
+	testPredicate(t, "isDuffinian", isDuffinian, []predicateTest{
+		{35, true}, // σ = 48
+		{49, true},
+		{77, true}, // σ = 96
+		{4, true},
+		{7, false},  // Prime
+		{28, false}, // σ = 56 shares 28
+		{6, false},
+		{1, false},
+	})
+}
+
+func TestIsHoax(t *testing.T) {
+	testPredicate(t, "isHoax", isHoax, []predicateTest{
+		{22, true}, // 2+2 = 2 + 1+1
+		{58, true},
+		{84, true}, // 2² × 3 × 7, counting 2 once; not a Smith number
+		{85, true},
+		{13, false}, // Prime
+		{4, false},  // 4 ≠ 2
+		{27, false},
+	})
+}
+
+func TestDigitEconomy(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{125, economyFrugal}, // 5^3 takes 2 digits
+		{10, economyEquidigital},
+		{1, economyEquidigital},
+		{6, economyExtravagant}, // 2 × 3 takes 2 digits
+		{4, economyExtravagant}, // 2^2 takes 2 digits
+		{0, ""},
+		{-125, ""},
+	}
+	for _, tt := range tests {
+		if got, ok := digitEconomy(tt.n); got != tt.want || ok != (tt.want != "") {
+			t.Errorf("digitEconomy(%d) = %q, %v, want %q", tt.n, got, ok, tt.want)
+		}
+	}
+}
+
+func TestIsKeith(t *testing.T) {
+	testPredicate(t, "isKeith", isKeith, []predicateTest{
+		{14, true},
+		{19, true},
+		{28, true},
+		{197, true},
+		{742, true},
+		{7, false}, // Single digits are excluded
+		{15, false},
+		{-14, false},
+		{math.MaxInt, false}, // The sequence overflows before it can reach n
+		{math.MaxInt - 1, false},
+	})
+}
+
+func TestReverseDigits(t *testing.T) {
+	tests := []struct {
+		n, want int
+		ok      bool
+	}{
+		{1200, 21, true},
+		{100, 1, true},
+		{-53, -35, true},
+		{0, 0, true},
+		{1999999999999999999, 0, false}, // 9999999999999999991 is past MaxInt
+	}
+	for _, tt := range tests {
+		if got, ok := reverseDigits(tt.n); got != tt.want || ok != tt.ok {
+			t.Errorf("reverseDigits(%d) = %d, %v, want %d, %v", tt.n, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestIsPowerOfTwo(t *testing.T) {
+	testPredicate(t, "isPowerOfTwo", isPowerOfTwo, []predicateTest{
+		{1, true},
+		{2, true},
+		{1024, true},
+		{1 << 62, true},
+		{0, false},
+		{-2, false},
+		{math.MinInt, false},
+		{3, false},
+		{6, false},
+		{math.MaxInt, false},
+	})
+}
+
+func TestIsPowerOf(t *testing.T) {
+	tests := []struct {
+		n, base int
+		want    bool
+	}{
+		{81, 3, true},
+		{1, 3, true}, // 3^0
+		{1, 10, true},
+		{4052555153018976267, 3, true}, // 3^39, the largest power of 3 in an int
+		{1000, 10, true},
+		{82, 3, false},
+		{27, 9, false},
+		{0, 3, false},
+		{-81, 3, false},
+	}
+	for _, tt := range tests {
+		if got := isPowerOf(tt.n, tt.base); got != tt.want {
+			t.Errorf("isPowerOf(%d, %d) = %v, want %v", tt.n, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestFigurateIndex(t *testing.T) {
+	tests := []struct {
+		name  string
+		index func(int) (int, bool)
+		n, k  int
+		ok    bool
+	}{
+		{"triangularIndex", triangularIndex, 55, 10, true},
+		{"triangularIndex", triangularIndex, 0, 0, true},
+		{"triangularIndex", triangularIndex, 9223372034707292160, 4294967295, true}, // The largest in an int
+		{"triangularIndex", triangularIndex, 100, 0, false},
+		{"triangularIndex", triangularIndex, -1, 0, false},
+		{"squareIndex", squareIndex, 100, 10, true},
+		{"squareIndex", squareIndex, 0, 0, true},
+		{"squareIndex", squareIndex, 9223372030926249001, 3037000499, true},
+		{"squareIndex", squareIndex, 55, 0, false},
+		{"squareIndex", squareIndex, -4, 0, false},
+	}
+	for _, tt := range tests {
+		k, ok := tt.index(tt.n)
+		if ok != tt.ok || (ok && k != tt.k) {
+			t.Errorf("%s(%d) = %d, %v, want %d, %v", tt.name, tt.n, k, ok, tt.k, tt.ok)
+		}
+	}
+}
+
+func TestIsSelfNumber(t *testing.T) {
+	testPredicate(t, "isSelfNumber", isSelfNumber, []predicateTest{
+		{1, true},
+		{3, true},
+		{5, true},
+		{7, true},
+		{9, true},
+		{20, true},
+		{31, true},
+		{108, true},
+		{2, false},  // 1 + 1
+		{11, false}, // 10 + 1
+		{21, false}, // 15 + 1+5
+		{0, false},
+		{-1, false},
+	})
+}
+
+func TestIsPandigital(t *testing.T) {
+	tests := []struct {
+		n, base  int
+		zeroless bool
+		want     bool
+	}{
+		{1234567890, 10, false, true},
+		{1234567890, 10, true, true},
+		{1023456789, 10, false, true},
+		{123456789, 10, false, false}, // No 0
+		{123456789, 10, true, true},
+		{-1234567890, 10, false, true},
+		{123456780, 10, true, false}, // No 9
+		{2, 2, false, true},          // 10 in binary
+		{3, 2, false, false},         // 11
+		{3, 2, true, true},
+	}
+	for _, tt := range tests {
+		if got := isPandigital(tt.n, tt.base, tt.zeroless); got != tt.want {
+			t.Errorf("isPandigital(%d, %d, %v) = %v, want %v", tt.n, tt.base, tt.zeroless, got, tt.want)
+		}
+	}
+}
+
+func TestIsPowerful(t *testing.T) {
+	testPredicate(t, "isPowerful", isPowerful, []predicateTest{
+		{1, true},
+		{4, true},
+		{36, true},
+		{72, true},
+		{2, false},
+		{12, false}, // 2² × 3
+		{0, false},
+	})
+}
+
+func TestIsPerfectPower(t *testing.T) {
+	testPredicate(t, "isPerfectPower", isPerfectPower, []predicateTest{
+		{1, true},
+		{8, true},
+		{36, true},
+		{216, true}, // 6³
+		{72, false}, // 2³ × 3²
+		{12, false},
+		{0, false},
+		{-8, false},
+	})
+}
+
+func TestIsBigArmstrong(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   bool
+	}{
+		{"115132219018763992565095597973971522400", true}, // The largest two, 39 digits
+		{"115132219018763992565095597973971522401", true},
+		{"115132219018763992565095597973971522402", false},
+		{strings.Repeat("9", 61), false},
+	}
+	for _, tt := range tests {
+		abs, _ := new(big.Int).SetString(tt.digits, 10)
+		if got := isBigArmstrong(tt.digits, abs); got != tt.want {
+			t.Errorf("isBigArmstrong(%s) = %v, want %v", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyBigArmstrong(t *testing.T) {
+	n, _ := new(big.Int).SetString("-115132219018763992565095597973971522400", 10)
+	if got := classifyBig(n).Properties; !slices.Equal(got, []string{"armstrong", "even"}) {
+		t.Errorf("properties = %v, want [armstrong even]", got)
+	}
+}
+
+func TestIsUndulating(t *testing.T) {
+	testPredicate(t, "isUndulating", isUndulating, []predicateTest{
+		{272, true},
+		{5454, true},
+		{4343, true},
+		{171717, true},
+		{-121, true},
+		{1234, false},
+		{1221, false},
+		{111, false}, // Needs two distinct digits
+		{12, false},  // Too short to alternate
+		{7, false},
+	})
+}
+
+func TestIsCircularPrime(t *testing.T) {
+	testPredicate(t, "isCircularPrime", isCircularPrime, []predicateTest{
+		{197, true}, // 971 and 719 are prime too
+		{113, true},
+		{2, true},
+		{11, true},
+		{199, true},
+		{19, false}, // 91 = 7 × 13
+		{23, false},
+		{101, false}, // The rotation 011 is 11, but 110 is even
+		{1, false},
+		{-13, false},
+	})
+}
+
+func TestIsPrimorial(t *testing.T) {
+	testPredicate(t, "isPrimorial", isPrimorial, []predicateTest{
+		{1, true}, // 0#, the empty product
+		{2, true},
+		{30, true},
+		{210, true},
+		{2310, true},
+		{60, false}, // 2² × 3 × 5
+		{3, false},
+		{0, false},
+	})
+}
+
+func TestIsDisarium(t *testing.T) {
+	testPredicate(t, "isDisarium", isDisarium, []predicateTest{
+		{89, true}, // 8¹ + 9²
+		{135, true},
+		{175, true},
+		{518, true},
+		{1306, true},
+		{-175, true},
+		{176, false},
+		{10, false},
+	})
+
+	// The largest Disarium number is past MaxInt64, so only exact mode sees it
+	for digits, want := range map[string]bool{
+		"12157692622039623539":    true,
+		"12157692622039623540":    false,
+		"10000000000000000000000": false,
+	} {
+		abs, _ := new(big.Int).SetString(digits, 10)
+		if got := isBigDisarium(digits, abs); got != want {
+			t.Errorf("isBigDisarium(%s) = %v, want %v", digits, got, want)
+		}
+	}
+}
+
+func TestIsBinaryPalindrome(t *testing.T) {
+	testPredicate(t, "isBinaryPalindrome", isBinaryPalindrome, []predicateTest{
+		{5, true},  // 101
+		{9, true},  // 1001
+		{33, true}, // 100001
+		{0, true},
+		{-5, true},
+		{math.MaxInt, true}, // 63 ones
+		{6, false},          // 110
+		{10, false},         // 1010
+		{math.MinInt, false},
+	})
+}
+
+func TestIsqrt(t *testing.T) {
+	tests := []struct{ n, want int }{
+		{-5, 0},
+		{0, 0},
+		{1, 1},
+		{15, 3},
+		{16, 4},
+		{1<<52 + 1, 1 << 26},
+		{94906267*94906267 - 1, 94906266},       // float64 rounds n up to the square
+		{3037000499*3037000499 - 1, 3037000498}, // Just below the largest square in an int; (2^32-1)² overflows
+		{3037000499 * 3037000499, 3037000499},
+		{math.MaxInt, 3037000499},
+	}
+	for _, tt := range tests {
+		if got := isqrt(tt.n); got != tt.want {
+			t.Errorf("isqrt(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestIroot(t *testing.T) {
+	tests := []struct{ n, k, want int }{
+		{0, 3, 0},
+		{1, 5, 1},
+		{1000, 3, 10},
+		{999, 3, 9},
+		{1e18, 3, 1e6},
+		{1e18 - 1, 3, 999999}, // math.Pow rounds up to 1e6
+		{math.MaxInt, 2, 3037000499},
+		{math.MaxInt, 3, 2097151}, // 2097152³ = 2^63
+		{math.MaxInt, 62, 2},
+		{math.MaxInt, 63, 1},
+	}
+	for _, tt := range tests {
+		if got := iroot(tt.n, tt.k); got != tt.want {
+			t.Errorf("iroot(%d, %d) = %d, want %d", tt.n, tt.k, got, tt.want)
+		}
+	}
+}
+
+func TestIsEvil(t *testing.T) {
+	tests := []predicateTest{
+		{0, true}, // No one bits
+		{3, true},
+		{5, true},
+		{6, true},
+		{-3, true},
+		{1, false},
+		{2, false},
+		{7, false},
+		{math.MinInt, false}, // 2^63, a single one bit
+	}
+	testPredicate(t, "isEvil", isEvil, tests)
+	for i := range tests {
+		tests[i].want = !tests[i].want
+	}
+	testPredicate(t, "isOdious", isOdious, tests)
+}
+
+func TestIsHighlyComposite(t *testing.T) {
+	testPredicate(t, "isHighlyComposite", isHighlyComposite, []predicateTest{
+		{1, true},
+		{2, true},
+		{12, true},
+		{36, true},
+		{720720, true},
+		{3, false},
+		{8, false},   // 4 divisors, like 6
+		{200, false}, // 12 divisors, fewer than 120 has
+		{0, false},
+	})
+	// Each term has more divisors than the one before
+	for i := 1; i < len(highlyCompositeNumbers); i++ {
+		prev, cur := highlyCompositeNumbers[i-1], highlyCompositeNumbers[i]
+		if divisorCount(factorize(cur)) <= divisorCount(factorize(prev)) {
+			t.Errorf("%d has no more divisors than %d", cur, prev)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package numclass
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone deep-copies a classification, so holders of one never alias each
+// other's slices, maps or pointers.
+func (r Classification) Clone() Classification {
+	r.IsPowerOf = clonePointer(r.IsPowerOf)
+	r.TriangularIndex = clonePointer(r.TriangularIndex)
+	r.SquareIndex = clonePointer(r.SquareIndex)
+	r.Reversed = clonePointer(r.Reversed)
+	r.Abundance = clonePointer(r.Abundance)
+	r.DigitEconomy = clonePointer(r.DigitEconomy)
+	r.FunFactError = clonePointer(r.FunFactError)
+	r.Properties = slices.Clone(r.Properties)
+	r.Undefined = slices.Clone(r.Undefined)
+	r.Sequences = slices.Clone(r.Sequences)
+	r.Timings = maps.Clone(r.Timings)
+	r.Cached = clonePointer(r.Cached)
+	r.Explanations = maps.Clone(r.Explanations)
+	r.BitInfo = clonePointer(r.BitInfo)
+	r.InterestingScore = clonePointer(r.InterestingScore)
+	r.DigitStats = clonePointer(r.DigitStats)
+	r.FunFacts = maps.Clone(r.FunFacts)
+	if r.Verbose != nil {
+		v := *r.Verbose
+		v.AllProperties = slices.Clone(v.AllProperties)
+		v.Roman = clonePointer(v.Roman)
+		v.Factorization = slices.Clone(v.Factorization)
+		v.Divisors = slices.Clone(v.Divisors)
+		v.Totient = clonePointer(v.Totient)
+		v.DivisorCount = clonePointer(v.DivisorCount)
+		v.FactorizationText = clonePointer(v.FactorizationText)
+		r.Verbose = &v
+	}
+	return r
+}
+
+// clonePointer returns a pointer to a copy of *p, or nil.
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
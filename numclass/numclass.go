@@ -0,0 +1,158 @@
+// Package numclass holds the response types of the Number Classification
+// API, shared by the server and by Go clients.
+package numclass
+
+import "time"
+
+// Classification is the result of classifying a number, shared by the HTTP
+// and gRPC transports and by the client package.
+//
+// Fields serialize in declaration order, which is the documented response
+// order, so new fields are appended and existing ones never reordered.
+type Classification struct {
+	Number               int                `json:"number"`
+	IsPrime              bool               `json:"is_prime"`
+	IsPerfect            bool               `json:"is_perfect"`
+	IsPractical          bool               `json:"is_practical"`
+	IsPowerOfTwo         bool               `json:"is_power_of_two"`
+	IsPowerOf            *bool              `json:"is_power_of,omitempty"` // Only with ?power_base=
+	PowerBase            int                `json:"power_base,omitempty"`
+	IsTriangular         bool               `json:"is_triangular"`
+	TriangularIndex      *int               `json:"triangular_index"` // k with k(k+1)/2 = number, else null
+	IsSquare             bool               `json:"is_square"`
+	SquareIndex          *int               `json:"square_index"` // k with k² = number, else null
+	IsEvil               bool               `json:"is_evil"`      // Even number of 1 bits in |number|
+	IsOdious             bool               `json:"is_odious"`    // Odd number of 1 bits in |number|
+	Properties           []string           `json:"properties"`
+	DigitSum             int                `json:"digit_sum"`
+	Reversed             *int               `json:"reversed"` // null if the reversal overflows
+	FunFact              string             `json:"fun_fact"`
+	IsCarmichael         bool               `json:"is_carmichael"`  // Composite that fools the Fermat test
+	IsSelfNumber         bool               `json:"is_self_number"` // Not m + digitSum(m) for any m
+	IsSphenic            bool               `json:"is_sphenic"`     // Product of three distinct primes
+	IsPandigital         bool               `json:"is_pandigital"`  // Every digit of the base appears
+	IsZerolessPandigital bool               `json:"is_zeroless_pandigital"`
+	PandigitalBase       int                `json:"pandigital_base,omitempty"`   // Only with ?pandigital_base=
+	Abundance            *int               `json:"abundance"`                   // Aliquot sum minus number; null below 1 or past the int range
+	Sign                 string             `json:"sign"`                        // "negative", "zero" or "positive"
+	Undefined            []string           `json:"undefined"`                   // Fields false only because they aren't defined for negatives
+	IsAchilles           bool               `json:"is_achilles"`                 // Powerful but not a perfect power
+	IsUndulating         bool               `json:"is_undulating"`               // Digits alternate a-b-a-b...
+	IsCircularPrime      bool               `json:"is_circular_prime"`           // Every digit rotation is prime
+	IsPrimorial          bool               `json:"is_primorial"`                // Product of the first k primes
+	IsDuffinian          bool               `json:"is_duffinian"`                // Composite and coprime to its divisor sum
+	IsHoax               bool               `json:"is_hoax"`                     // Composite; digit sum matches its distinct prime factors'
+	IsKeith              bool               `json:"is_keith"`                    // In the sequence its own digits start
+	IsDisarium           bool               `json:"is_disarium"`                 // Digits raised to their positions sum to it
+	DigitEconomy         *string            `json:"digit_economy"`               // frugal, equidigital or extravagant; null below 1
+	IsBinaryPalindrome   bool               `json:"is_binary_palindrome"`        // Binary form of the magnitude reads the same both ways
+	FunFactSource        string             `json:"fun_fact_source"`             // numbersapi, cache, fallback or static
+	FunFactError         *string            `json:"fun_fact_error"`              // Why fun_fact is the fallback: timeout, upstream_error, decode_error or no_fact; else null
+	IsHighlyComposite    bool               `json:"is_highly_composite"`         // More divisors than any smaller positive integer
+	Timings              map[string]float64 `json:"timings,omitempty"`           // Per-step milliseconds, debug only
+	Verbose              *VerboseDetails    `json:"verbose,omitempty"`           // Only with ?verbose=true
+	Formatted            string             `json:"formatted,omitempty"`         // Only with ?formatted=true
+	Explanations         map[string]string  `json:"explanations,omitempty"`      // Property name -> reasoning, only with ?explain=true
+	Sequences            []Sequence         `json:"sequences,omitempty"`         // Only with ?sequences=true
+	BitInfo              *BitInfo           `json:"bit_info,omitempty"`          // Only with ?bit_info=true
+	InterestingScore     *int               `json:"interesting_score,omitempty"` // Rarity-weighted property tally, only with ?interesting_score=true
+	DigitStats           *DigitStats        `json:"digit_stats,omitempty"`       // Only with ?digit_stats=true
+	FunFacts             map[string]string  `json:"fun_facts,omitempty"`         // Category -> fact, only with several ?fact_types=
+	Cached               *bool              `json:"cached,omitempty"`            // Served from the precompute table or fun-fact cache, debug only
+}
+
+// VerboseDetails is everything ?verbose=true adds to a classification.
+type VerboseDetails struct {
+	AllProperties   []string        `json:"all_properties"` // Every registry property that holds
+	Representations Representations `json:"representations"`
+	Words           string          `json:"words"`
+	Roman           *string         `json:"roman"`         // null outside 1-3999
+	Factorization   []PrimeFactor   `json:"factorization"` // Positive numbers only, else null
+	Divisors        []int           `json:"divisors"`
+	Totient         *int            `json:"totient"`
+
+	DivisorCount           *int `json:"divisor_count"`           // All divisors, even when the list is cut short
+	DivisorsTruncated      bool `json:"divisors_truncated"`      // Divisors lists only the smallest MAX_DIVISORS
+	FactorizationTruncated bool `json:"factorization_truncated"` // Factorization lists only the smallest MAX_DIVISORS primes
+
+	FactorizationText *string `json:"factorization_text,omitempty"` // The whole factorization as ?factor_format= writes it
+}
+
+// Representations is a number written in the common bases.
+type Representations struct {
+	Binary      string `json:"binary"`
+	Octal       string `json:"octal"`
+	Decimal     string `json:"decimal"`
+	Hexadecimal string `json:"hexadecimal"`
+}
+
+// BitInfo is the number's bit pattern at a fixed width, two's complement for
+// negatives, as ?bit_info=true reports it.
+type BitInfo struct {
+	Width         int    `json:"width"`
+	Binary        string `json:"binary"` // Zero-padded to Width
+	Popcount      int    `json:"popcount"`
+	BitLength     int    `json:"bit_length"` // Position of the highest set bit, 0 for 0
+	LeadingZeros  int    `json:"leading_zeros"`
+	TrailingZeros int    `json:"trailing_zeros"` // Width for 0
+	GrayCode      uint64 `json:"gray_code"`      // Pattern XOR itself shifted right once
+	ReversedBits  uint64 `json:"reversed_bits"`  // Pattern with its Width bits in reverse order
+}
+
+// PrimeFactor is a prime and its exponent in a factorization.
+type PrimeFactor struct {
+	Prime    int `json:"prime"`
+	Exponent int `json:"exponent"`
+}
+
+// Sequence describes the integer sequence behind a registry property.
+type Sequence struct {
+	Property    string  `json:"property"`
+	Name        string  `json:"name"`
+	OEIS        *string `json:"oeis"` // A-number, or null when no OEIS entry matches exactly
+	Description string  `json:"description"`
+}
+
+// Job statuses.
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+)
+
+// Job is an asynchronous batch classification, as POST /api/jobs and
+// GET /api/jobs/:id report it.
+type Job struct {
+	ID          string           `json:"id"`
+	Status      string           `json:"status"`
+	Total       int              `json:"total"`
+	Completed   int              `json:"completed"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	CallbackURL string           `json:"callback_url,omitempty"`
+	Results     []Classification `json:"results,omitempty"` // Filled in once the job is done
+	Dedup       *JobDedup        `json:"dedup,omitempty"`   // Only with ?debug=true
+}
+
+// JobDedup is how many of a job's numbers were classified. Repeats of a
+// number reuse its first result.
+type JobDedup struct {
+	Unique     int `json:"unique"`     // Distinct numbers, each classified once
+	Duplicates int `json:"duplicates"` // Entries answered from an earlier entry's result
+}
+
+// DigitStats summarizes the base-10 digits of the number's magnitude, as
+// ?digit_stats=true reports it.
+type DigitStats struct {
+	Count              int     `json:"count"`
+	Min                int     `json:"min"`
+	Max                int     `json:"max"`
+	Mean               float64 `json:"mean"`
+	Median             float64 `json:"median"` // Mean of the middle two for an even count
+	EvenCount          int     `json:"even_count"`
+	OddCount           int     `json:"odd_count"`
+	StrictlyIncreasing bool    `json:"strictly_increasing"` // Left to right; a single digit is all three
+	StrictlyDecreasing bool    `json:"strictly_decreasing"`
+	NonDecreasing      bool    `json:"non_decreasing"`
+}
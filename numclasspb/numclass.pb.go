@@ -0,0 +1,588 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: numclasspb/numclass.proto
+
+package numclasspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ClassifyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *ClassifyRequest) Reset() {
+	*x = ClassifyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_numclasspb_numclass_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClassifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyRequest) ProtoMessage() {}
+
+func (x *ClassifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_numclasspb_numclass_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyRequest.ProtoReflect.Descriptor instead.
+func (*ClassifyRequest) Descriptor() ([]byte, []int) {
+	return file_numclasspb_numclass_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ClassifyRequest) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type ClassifyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	IsPrime              bool     `protobuf:"varint,2,opt,name=is_prime,json=isPrime,proto3" json:"is_prime,omitempty"`
+	IsPerfect            bool     `protobuf:"varint,3,opt,name=is_perfect,json=isPerfect,proto3" json:"is_perfect,omitempty"`
+	Properties           []string `protobuf:"bytes,4,rep,name=properties,proto3" json:"properties,omitempty"`
+	DigitSum             int64    `protobuf:"varint,5,opt,name=digit_sum,json=digitSum,proto3" json:"digit_sum,omitempty"`
+	FunFact              string   `protobuf:"bytes,6,opt,name=fun_fact,json=funFact,proto3" json:"fun_fact,omitempty"`
+	IsPractical          bool     `protobuf:"varint,7,opt,name=is_practical,json=isPractical,proto3" json:"is_practical,omitempty"`
+	Reversed             *int64   `protobuf:"varint,8,opt,name=reversed,proto3,oneof" json:"reversed,omitempty"`
+	IsPowerOfTwo         bool     `protobuf:"varint,9,opt,name=is_power_of_two,json=isPowerOfTwo,proto3" json:"is_power_of_two,omitempty"`
+	IsTriangular         bool     `protobuf:"varint,10,opt,name=is_triangular,json=isTriangular,proto3" json:"is_triangular,omitempty"`
+	TriangularIndex      *int64   `protobuf:"varint,11,opt,name=triangular_index,json=triangularIndex,proto3,oneof" json:"triangular_index,omitempty"`
+	IsSquare             bool     `protobuf:"varint,12,opt,name=is_square,json=isSquare,proto3" json:"is_square,omitempty"`
+	SquareIndex          *int64   `protobuf:"varint,13,opt,name=square_index,json=squareIndex,proto3,oneof" json:"square_index,omitempty"`
+	IsEvil               bool     `protobuf:"varint,14,opt,name=is_evil,json=isEvil,proto3" json:"is_evil,omitempty"`
+	IsOdious             bool     `protobuf:"varint,15,opt,name=is_odious,json=isOdious,proto3" json:"is_odious,omitempty"`
+	IsCarmichael         bool     `protobuf:"varint,16,opt,name=is_carmichael,json=isCarmichael,proto3" json:"is_carmichael,omitempty"`
+	IsSelfNumber         bool     `protobuf:"varint,17,opt,name=is_self_number,json=isSelfNumber,proto3" json:"is_self_number,omitempty"`
+	IsSphenic            bool     `protobuf:"varint,18,opt,name=is_sphenic,json=isSphenic,proto3" json:"is_sphenic,omitempty"`
+	IsPandigital         bool     `protobuf:"varint,19,opt,name=is_pandigital,json=isPandigital,proto3" json:"is_pandigital,omitempty"`
+	IsZerolessPandigital bool     `protobuf:"varint,20,opt,name=is_zeroless_pandigital,json=isZerolessPandigital,proto3" json:"is_zeroless_pandigital,omitempty"`
+	Abundance            *int64   `protobuf:"varint,21,opt,name=abundance,proto3,oneof" json:"abundance,omitempty"`
+	Sign                 string   `protobuf:"bytes,22,opt,name=sign,proto3" json:"sign,omitempty"`
+	Undefined            []string `protobuf:"bytes,23,rep,name=undefined,proto3" json:"undefined,omitempty"`
+	IsAchilles           bool     `protobuf:"varint,24,opt,name=is_achilles,json=isAchilles,proto3" json:"is_achilles,omitempty"`
+	IsUndulating         bool     `protobuf:"varint,25,opt,name=is_undulating,json=isUndulating,proto3" json:"is_undulating,omitempty"`
+	IsCircularPrime      bool     `protobuf:"varint,26,opt,name=is_circular_prime,json=isCircularPrime,proto3" json:"is_circular_prime,omitempty"`
+	IsPrimorial          bool     `protobuf:"varint,27,opt,name=is_primorial,json=isPrimorial,proto3" json:"is_primorial,omitempty"`
+	IsDuffinian          bool     `protobuf:"varint,28,opt,name=is_duffinian,json=isDuffinian,proto3" json:"is_duffinian,omitempty"`
+	IsHoax               bool     `protobuf:"varint,29,opt,name=is_hoax,json=isHoax,proto3" json:"is_hoax,omitempty"`
+	IsKeith              bool     `protobuf:"varint,30,opt,name=is_keith,json=isKeith,proto3" json:"is_keith,omitempty"`
+	IsDisarium           bool     `protobuf:"varint,31,opt,name=is_disarium,json=isDisarium,proto3" json:"is_disarium,omitempty"`
+	DigitEconomy         *string  `protobuf:"bytes,32,opt,name=digit_economy,json=digitEconomy,proto3,oneof" json:"digit_economy,omitempty"`
+	IsBinaryPalindrome   bool     `protobuf:"varint,33,opt,name=is_binary_palindrome,json=isBinaryPalindrome,proto3" json:"is_binary_palindrome,omitempty"`
+	FunFactSource        string   `protobuf:"bytes,34,opt,name=fun_fact_source,json=funFactSource,proto3" json:"fun_fact_source,omitempty"`
+	FunFactError         *string  `protobuf:"bytes,35,opt,name=fun_fact_error,json=funFactError,proto3,oneof" json:"fun_fact_error,omitempty"`
+	IsHighlyComposite    bool     `protobuf:"varint,36,opt,name=is_highly_composite,json=isHighlyComposite,proto3" json:"is_highly_composite,omitempty"`
+}
+
+func (x *ClassifyResponse) Reset() {
+	*x = ClassifyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_numclasspb_numclass_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClassifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassifyResponse) ProtoMessage() {}
+
+func (x *ClassifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_numclasspb_numclass_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassifyResponse.ProtoReflect.Descriptor instead.
+func (*ClassifyResponse) Descriptor() ([]byte, []int) {
+	return file_numclasspb_numclass_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ClassifyResponse) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *ClassifyResponse) GetIsPrime() bool {
+	if x != nil {
+		return x.IsPrime
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsPerfect() bool {
+	if x != nil {
+		return x.IsPerfect
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetProperties() []string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *ClassifyResponse) GetDigitSum() int64 {
+	if x != nil {
+		return x.DigitSum
+	}
+	return 0
+}
+
+func (x *ClassifyResponse) GetFunFact() string {
+	if x != nil {
+		return x.FunFact
+	}
+	return ""
+}
+
+func (x *ClassifyResponse) GetIsPractical() bool {
+	if x != nil {
+		return x.IsPractical
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetReversed() int64 {
+	if x != nil && x.Reversed != nil {
+		return *x.Reversed
+	}
+	return 0
+}
+
+func (x *ClassifyResponse) GetIsPowerOfTwo() bool {
+	if x != nil {
+		return x.IsPowerOfTwo
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsTriangular() bool {
+	if x != nil {
+		return x.IsTriangular
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetTriangularIndex() int64 {
+	if x != nil && x.TriangularIndex != nil {
+		return *x.TriangularIndex
+	}
+	return 0
+}
+
+func (x *ClassifyResponse) GetIsSquare() bool {
+	if x != nil {
+		return x.IsSquare
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetSquareIndex() int64 {
+	if x != nil && x.SquareIndex != nil {
+		return *x.SquareIndex
+	}
+	return 0
+}
+
+func (x *ClassifyResponse) GetIsEvil() bool {
+	if x != nil {
+		return x.IsEvil
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsOdious() bool {
+	if x != nil {
+		return x.IsOdious
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsCarmichael() bool {
+	if x != nil {
+		return x.IsCarmichael
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsSelfNumber() bool {
+	if x != nil {
+		return x.IsSelfNumber
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsSphenic() bool {
+	if x != nil {
+		return x.IsSphenic
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsPandigital() bool {
+	if x != nil {
+		return x.IsPandigital
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsZerolessPandigital() bool {
+	if x != nil {
+		return x.IsZerolessPandigital
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetAbundance() int64 {
+	if x != nil && x.Abundance != nil {
+		return *x.Abundance
+	}
+	return 0
+}
+
+func (x *ClassifyResponse) GetSign() string {
+	if x != nil {
+		return x.Sign
+	}
+	return ""
+}
+
+func (x *ClassifyResponse) GetUndefined() []string {
+	if x != nil {
+		return x.Undefined
+	}
+	return nil
+}
+
+func (x *ClassifyResponse) GetIsAchilles() bool {
+	if x != nil {
+		return x.IsAchilles
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsUndulating() bool {
+	if x != nil {
+		return x.IsUndulating
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsCircularPrime() bool {
+	if x != nil {
+		return x.IsCircularPrime
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsPrimorial() bool {
+	if x != nil {
+		return x.IsPrimorial
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsDuffinian() bool {
+	if x != nil {
+		return x.IsDuffinian
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsHoax() bool {
+	if x != nil {
+		return x.IsHoax
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsKeith() bool {
+	if x != nil {
+		return x.IsKeith
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetIsDisarium() bool {
+	if x != nil {
+		return x.IsDisarium
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetDigitEconomy() string {
+	if x != nil && x.DigitEconomy != nil {
+		return *x.DigitEconomy
+	}
+	return ""
+}
+
+func (x *ClassifyResponse) GetIsBinaryPalindrome() bool {
+	if x != nil {
+		return x.IsBinaryPalindrome
+	}
+	return false
+}
+
+func (x *ClassifyResponse) GetFunFactSource() string {
+	if x != nil {
+		return x.FunFactSource
+	}
+	return ""
+}
+
+func (x *ClassifyResponse) GetFunFactError() string {
+	if x != nil && x.FunFactError != nil {
+		return *x.FunFactError
+	}
+	return ""
+}
+
+func (x *ClassifyResponse) GetIsHighlyComposite() bool {
+	if x != nil {
+		return x.IsHighlyComposite
+	}
+	return false
+}
+
+var File_numclasspb_numclass_proto protoreflect.FileDescriptor
+
+var file_numclasspb_numclass_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x6e, 0x75, 0x6d, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x70, 0x62, 0x2f, 0x6e, 0x75, 0x6d,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6e, 0x75, 0x6d,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x29, 0x0a, 0x0f, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x22, 0xe3, 0x0a, 0x0a, 0x10, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x73, 0x5f, 0x70, 0x65, 0x72, 0x66, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x69, 0x73, 0x50, 0x65, 0x72, 0x66, 0x65, 0x63, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69,
+	0x67, 0x69, 0x74, 0x5f, 0x73, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x69, 0x67, 0x69, 0x74, 0x53, 0x75, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x75, 0x6e, 0x5f, 0x66,
+	0x61, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66, 0x75, 0x6e, 0x46, 0x61,
+	0x63, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x70, 0x72, 0x61, 0x63, 0x74, 0x69, 0x63,
+	0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x50, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x63, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x76, 0x65, 0x72,
+	0x73, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0f, 0x69, 0x73, 0x5f, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x74, 0x77, 0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x69, 0x73, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x66, 0x54, 0x77, 0x6f, 0x12, 0x23, 0x0a,
+	0x0d, 0x69, 0x73, 0x5f, 0x74, 0x72, 0x69, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x54, 0x72, 0x69, 0x61, 0x6e, 0x67, 0x75, 0x6c,
+	0x61, 0x72, 0x12, 0x2e, 0x0a, 0x10, 0x74, 0x72, 0x69, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x0f,
+	0x74, 0x72, 0x69, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x88,
+	0x01, 0x01, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x12,
+	0x26, 0x0a, 0x0c, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x0b, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x73, 0x5f, 0x65, 0x76,
+	0x69, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x45, 0x76, 0x69, 0x6c,
+	0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x6f, 0x64, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x4f, 0x64, 0x69, 0x6f, 0x75, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x69, 0x73, 0x5f, 0x63, 0x61, 0x72, 0x6d, 0x69, 0x63, 0x68, 0x61, 0x65, 0x6c, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x43, 0x61, 0x72, 0x6d, 0x69, 0x63, 0x68, 0x61,
+	0x65, 0x6c, 0x12, 0x24, 0x0a, 0x0e, 0x69, 0x73, 0x5f, 0x73, 0x65, 0x6c, 0x66, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x53, 0x65,
+	0x6c, 0x66, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x73,
+	0x70, 0x68, 0x65, 0x6e, 0x69, 0x63, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73,
+	0x53, 0x70, 0x68, 0x65, 0x6e, 0x69, 0x63, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x70, 0x61,
+	0x6e, 0x64, 0x69, 0x67, 0x69, 0x74, 0x61, 0x6c, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x69, 0x73, 0x50, 0x61, 0x6e, 0x64, 0x69, 0x67, 0x69, 0x74, 0x61, 0x6c, 0x12, 0x34, 0x0a, 0x16,
+	0x69, 0x73, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x61, 0x6e, 0x64,
+	0x69, 0x67, 0x69, 0x74, 0x61, 0x6c, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x69, 0x73,
+	0x5a, 0x65, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x73, 0x50, 0x61, 0x6e, 0x64, 0x69, 0x67, 0x69, 0x74,
+	0x61, 0x6c, 0x12, 0x21, 0x0a, 0x09, 0x61, 0x62, 0x75, 0x6e, 0x64, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x03, 0x48, 0x03, 0x52, 0x09, 0x61, 0x62, 0x75, 0x6e, 0x64, 0x61, 0x6e,
+	0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x64,
+	0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x17, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x75, 0x6e,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x61, 0x63,
+	0x68, 0x69, 0x6c, 0x6c, 0x65, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73,
+	0x41, 0x63, 0x68, 0x69, 0x6c, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x75,
+	0x6e, 0x64, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x69, 0x73, 0x55, 0x6e, 0x64, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a,
+	0x11, 0x69, 0x73, 0x5f, 0x63, 0x69, 0x72, 0x63, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x70, 0x72, 0x69,
+	0x6d, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x73, 0x43, 0x69, 0x72, 0x63,
+	0x75, 0x6c, 0x61, 0x72, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f,
+	0x70, 0x72, 0x69, 0x6d, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x69, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c,
+	0x69, 0x73, 0x5f, 0x64, 0x75, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x61, 0x6e, 0x18, 0x1c, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x44, 0x75, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x61, 0x6e, 0x12,
+	0x17, 0x0a, 0x07, 0x69, 0x73, 0x5f, 0x68, 0x6f, 0x61, 0x78, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x69, 0x73, 0x48, 0x6f, 0x61, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x6b,
+	0x65, 0x69, 0x74, 0x68, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x4b, 0x65,
+	0x69, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x72, 0x69,
+	0x75, 0x6d, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x44, 0x69, 0x73, 0x61,
+	0x72, 0x69, 0x75, 0x6d, 0x12, 0x28, 0x0a, 0x0d, 0x64, 0x69, 0x67, 0x69, 0x74, 0x5f, 0x65, 0x63,
+	0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x0c, 0x64,
+	0x69, 0x67, 0x69, 0x74, 0x45, 0x63, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x88, 0x01, 0x01, 0x12, 0x30,
+	0x0a, 0x14, 0x69, 0x73, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x6c, 0x69,
+	0x6e, 0x64, 0x72, 0x6f, 0x6d, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x69, 0x73,
+	0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x50, 0x61, 0x6c, 0x69, 0x6e, 0x64, 0x72, 0x6f, 0x6d, 0x65,
+	0x12, 0x26, 0x0a, 0x0f, 0x66, 0x75, 0x6e, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x22, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x75, 0x6e, 0x46, 0x61,
+	0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x0e, 0x66, 0x75, 0x6e, 0x5f,
+	0x66, 0x61, 0x63, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x23, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x05, 0x52, 0x0c, 0x66, 0x75, 0x6e, 0x46, 0x61, 0x63, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x13, 0x69, 0x73, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x79,
+	0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x18, 0x24, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x11, 0x69, 0x73, 0x48, 0x69, 0x67, 0x68, 0x6c, 0x79, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x64,
+	0x42, 0x13, 0x0a, 0x11, 0x5f, 0x74, 0x72, 0x69, 0x61, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x61, 0x62, 0x75, 0x6e, 0x64,
+	0x61, 0x6e, 0x63, 0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x64, 0x69, 0x67, 0x69, 0x74, 0x5f, 0x65,
+	0x63, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x66, 0x75, 0x6e, 0x5f, 0x66,
+	0x61, 0x63, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x32, 0xae, 0x01, 0x0a, 0x10, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x47,
+	0x0a, 0x08, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x79, 0x12, 0x1c, 0x2e, 0x6e, 0x75, 0x6d,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x75, 0x6d, 0x63, 0x6c,
+	0x61, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0e, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x69, 0x66, 0x79, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1c, 0x2e, 0x6e, 0x75, 0x6d, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6e, 0x75, 0x6d, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x64, 0x69, 0x64, 0x61, 0x7a, 0x62,
+	0x6f, 0x74, 0x2f, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x5f, 0x61, 0x70, 0x69,
+	0x2f, 0x6e, 0x75, 0x6d, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_numclasspb_numclass_proto_rawDescOnce sync.Once
+	file_numclasspb_numclass_proto_rawDescData = file_numclasspb_numclass_proto_rawDesc
+)
+
+func file_numclasspb_numclass_proto_rawDescGZIP() []byte {
+	file_numclasspb_numclass_proto_rawDescOnce.Do(func() {
+		file_numclasspb_numclass_proto_rawDescData = protoimpl.X.CompressGZIP(file_numclasspb_numclass_proto_rawDescData)
+	})
+	return file_numclasspb_numclass_proto_rawDescData
+}
+
+var file_numclasspb_numclass_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_numclasspb_numclass_proto_goTypes = []interface{}{
+	(*ClassifyRequest)(nil),  // 0: numclass.v1.ClassifyRequest
+	(*ClassifyResponse)(nil), // 1: numclass.v1.ClassifyResponse
+}
+var file_numclasspb_numclass_proto_depIdxs = []int32{
+	0, // 0: numclass.v1.NumberClassifier.Classify:input_type -> numclass.v1.ClassifyRequest
+	0, // 1: numclass.v1.NumberClassifier.ClassifyStream:input_type -> numclass.v1.ClassifyRequest
+	1, // 2: numclass.v1.NumberClassifier.Classify:output_type -> numclass.v1.ClassifyResponse
+	1, // 3: numclass.v1.NumberClassifier.ClassifyStream:output_type -> numclass.v1.ClassifyResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_numclasspb_numclass_proto_init() }
+func file_numclasspb_numclass_proto_init() {
+	if File_numclasspb_numclass_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_numclasspb_numclass_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClassifyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_numclasspb_numclass_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClassifyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_numclasspb_numclass_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_numclasspb_numclass_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_numclasspb_numclass_proto_goTypes,
+		DependencyIndexes: file_numclasspb_numclass_proto_depIdxs,
+		MessageInfos:      file_numclasspb_numclass_proto_msgTypes,
+	}.Build()
+	File_numclasspb_numclass_proto = out.File
+	file_numclasspb_numclass_proto_rawDesc = nil
+	file_numclasspb_numclass_proto_goTypes = nil
+	file_numclasspb_numclass_proto_depIdxs = nil
+}
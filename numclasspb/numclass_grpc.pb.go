@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: numclasspb/numclass.proto
+
+package numclasspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	NumberClassifier_Classify_FullMethodName       = "/numclass.v1.NumberClassifier/Classify"
+	NumberClassifier_ClassifyStream_FullMethodName = "/numclass.v1.NumberClassifier/ClassifyStream"
+)
+
+// NumberClassifierClient is the client API for NumberClassifier service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NumberClassifierClient interface {
+	Classify(ctx context.Context, in *ClassifyRequest, opts ...grpc.CallOption) (*ClassifyResponse, error)
+	ClassifyStream(ctx context.Context, opts ...grpc.CallOption) (NumberClassifier_ClassifyStreamClient, error)
+}
+
+type numberClassifierClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNumberClassifierClient(cc grpc.ClientConnInterface) NumberClassifierClient {
+	return &numberClassifierClient{cc}
+}
+
+func (c *numberClassifierClient) Classify(ctx context.Context, in *ClassifyRequest, opts ...grpc.CallOption) (*ClassifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClassifyResponse)
+	err := c.cc.Invoke(ctx, NumberClassifier_Classify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *numberClassifierClient) ClassifyStream(ctx context.Context, opts ...grpc.CallOption) (NumberClassifier_ClassifyStreamClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NumberClassifier_ServiceDesc.Streams[0], NumberClassifier_ClassifyStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &numberClassifierClassifyStreamClient{ClientStream: stream}
+	return x, nil
+}
+
+type NumberClassifier_ClassifyStreamClient interface {
+	Send(*ClassifyRequest) error
+	Recv() (*ClassifyResponse, error)
+	grpc.ClientStream
+}
+
+type numberClassifierClassifyStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *numberClassifierClassifyStreamClient) Send(m *ClassifyRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *numberClassifierClassifyStreamClient) Recv() (*ClassifyResponse, error) {
+	m := new(ClassifyResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NumberClassifierServer is the server API for NumberClassifier service.
+// All implementations must embed UnimplementedNumberClassifierServer
+// for forward compatibility
+type NumberClassifierServer interface {
+	Classify(context.Context, *ClassifyRequest) (*ClassifyResponse, error)
+	ClassifyStream(NumberClassifier_ClassifyStreamServer) error
+	mustEmbedUnimplementedNumberClassifierServer()
+}
+
+// UnimplementedNumberClassifierServer must be embedded to have forward compatible implementations.
+type UnimplementedNumberClassifierServer struct {
+}
+
+func (UnimplementedNumberClassifierServer) Classify(context.Context, *ClassifyRequest) (*ClassifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Classify not implemented")
+}
+func (UnimplementedNumberClassifierServer) ClassifyStream(NumberClassifier_ClassifyStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ClassifyStream not implemented")
+}
+func (UnimplementedNumberClassifierServer) mustEmbedUnimplementedNumberClassifierServer() {}
+
+// UnsafeNumberClassifierServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NumberClassifierServer will
+// result in compilation errors.
+type UnsafeNumberClassifierServer interface {
+	mustEmbedUnimplementedNumberClassifierServer()
+}
+
+func RegisterNumberClassifierServer(s grpc.ServiceRegistrar, srv NumberClassifierServer) {
+	s.RegisterService(&NumberClassifier_ServiceDesc, srv)
+}
+
+func _NumberClassifier_Classify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClassifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NumberClassifierServer).Classify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NumberClassifier_Classify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NumberClassifierServer).Classify(ctx, req.(*ClassifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NumberClassifier_ClassifyStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NumberClassifierServer).ClassifyStream(&numberClassifierClassifyStreamServer{ServerStream: stream})
+}
+
+type NumberClassifier_ClassifyStreamServer interface {
+	Send(*ClassifyResponse) error
+	Recv() (*ClassifyRequest, error)
+	grpc.ServerStream
+}
+
+type numberClassifierClassifyStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *numberClassifierClassifyStreamServer) Send(m *ClassifyResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *numberClassifierClassifyStreamServer) Recv() (*ClassifyRequest, error) {
+	m := new(ClassifyRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NumberClassifier_ServiceDesc is the grpc.ServiceDesc for NumberClassifier service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NumberClassifier_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "numclass.v1.NumberClassifier",
+	HandlerType: (*NumberClassifierServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Classify",
+			Handler:    _NumberClassifier_Classify_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ClassifyStream",
+			Handler:       _NumberClassifier_ClassifyStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "numclasspb/numclass.proto",
+}
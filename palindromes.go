@@ -0,0 +1,112 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// palindromeRange is the body of GET /api/palindromes.
+type palindromeRange struct {
+	Start       int   `json:"start"`
+	End         int   `json:"end"`
+	Base        int   `json:"base"`
+	Count       int   `json:"count"`
+	Palindromes []int `json:"palindromes"`
+}
+
+// palindromesInRange lists the palindromes in [start, end], written in base
+// (default 10). They are built from their first half rather than found by
+// testing every number, so the cost tracks the answer, not the range.
+func palindromesInRange(c *gin.Context) {
+	start, ok := intQuery(c, "start", 0)
+	if !ok {
+		return
+	}
+	end, ok := intQuery(c, "end", 100)
+	if !ok {
+		return
+	}
+	base, ok := intQuery(c, "base", 10)
+	if !ok {
+		return
+	}
+
+	switch {
+	case start < 0 || end < start:
+		respondError(c, http.StatusBadRequest, c.Query("end"), "start must be non-negative and end must not be below start")
+		return
+	case end-start >= cfg.PalindromesMaxRange:
+		respondError(c, http.StatusBadRequest, c.Query("end"), "range exceeds the maximum allowed size")
+		return
+	case base < 2 || base > guessBaseMaxBase:
+		respondError(c, http.StatusBadRequest, c.Query("base"), "base must be between 2 and 36")
+		return
+	}
+
+	result := palindromeRange{Start: start, End: end, Base: base, Palindromes: []int{}}
+	forEachPalindrome(uint64(start), uint64(end), uint64(base), func(p uint64) {
+		result.Palindromes = append(result.Palindromes, int(p))
+	})
+	result.Count = len(result.Palindromes)
+	render(c, http.StatusOK, result)
+}
+
+// forEachPalindrome calls fn with each palindrome in [lo, hi] in ascending
+// order. For every digit length it counts up the first half (the middle
+// digit included for odd lengths) and mirrors it, so 12 becomes 121 and
+// 1221. A mirror that passes hi ends the walk long before uint64 wraps,
+// since consecutive palindromes are only about √hi apart.
+func forEachPalindrome(lo, hi, base uint64, fn func(uint64)) {
+	minLen, maxLen := digitLength(lo, base), digitLength(hi, base)
+	for length := minLen; length <= maxLen; length++ {
+		halfLen := (length + 1) / 2
+		first, last := powUint(base, halfLen-1), powUint(base, halfLen)-1
+		if length == 1 {
+			first = 0 // Single digits, including 0
+		}
+		if length == minLen {
+			first = max(first, lo/powUint(base, length-halfLen)) // Skip halves that start below lo
+		}
+		for half := first; half <= last; half++ {
+			p := mirror(half, base, length%2 == 1)
+			if p > hi {
+				return
+			}
+			if p >= lo {
+				fn(p)
+			}
+		}
+	}
+}
+
+// mirror appends the reversed digits of half to itself, dropping the last
+// digit of half from the copy when the palindrome has odd length.
+func mirror(half, base uint64, odd bool) uint64 {
+	p, rest := half, half
+	if odd {
+		rest /= base
+	}
+	for ; rest > 0; rest /= base {
+		p = p*base + rest%base
+	}
+	return p
+}
+
+// digitLength counts the digits of n in base; 0 has one digit.
+func digitLength(n, base uint64) int {
+	length := 1
+	for ; n >= base; n /= base {
+		length++
+	}
+	return length
+}
+
+// powUint returns base^exp.
+func powUint(base uint64, exp int) uint64 {
+	result := uint64(1)
+	for ; exp > 0; exp-- {
+		result *= base
+	}
+	return result
+}
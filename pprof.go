@@ -0,0 +1,20 @@
+package main
+
+import (
+	"net/http/pprof"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mountPprof serves the net/http/pprof handlers under /debug/pprof. It is
+// only called when PPROF_ENABLED is set, since profiles expose internals.
+func mountPprof(r *gin.Engine) {
+	g := r.Group("/debug/pprof")
+	g.GET("/", gin.WrapF(pprof.Index))
+	g.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+	g.GET("/profile", gin.WrapF(pprof.Profile))
+	g.GET("/symbol", gin.WrapF(pprof.Symbol))
+	g.POST("/symbol", gin.WrapF(pprof.Symbol))
+	g.GET("/trace", gin.WrapF(pprof.Trace))
+	g.GET("/:profile", gin.WrapF(pprof.Index)) // heap, goroutine, allocs, block, mutex, ...
+}
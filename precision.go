@@ -0,0 +1,272 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Precision modes for /api/classify-number.
+const (
+	precisionFast  = "fast"  // int64 only; decimals go through float64
+	precisionExact = "exact" // Exact decimal parsing, and big.Int beyond int64
+)
+
+// exactDecimal matches the plain decimal syntax accepted in exact mode.
+var exactDecimal = regexp.MustCompile(`^([+-]?)(\d*)(?:\.(\d*))?(?:[eE]([+-]?\d+))?$`)
+
+// errTooManyDigits is returned when an exact value would exceed EXACT_MAX_DIGITS.
+var errTooManyDigits = errors.New("number has too many digits for exact mode")
+
+// bigOmittedFields are the Classification fields that need a factorization,
+// a divisor walk or a primality test per digit, which is out of reach beyond
+// 64 bits.
+var bigOmittedFields = []string{
+	"is_perfect", "is_practical", "is_carmichael", "is_sphenic", "is_self_number",
+	"is_achilles", "is_circular_prime", "is_primorial",
+	"is_duffinian", "is_hoax", "is_keith", "digit_economy", "abundance", "fun_fact",
+	"fun_fact_source", "fun_fact_error", "is_highly_composite",
+}
+
+// bigClassification is the exact-mode result for a number outside the int64
+// range. Names match Classification; numbers that may not fit a float64 are
+// decimal strings so JSON clients don't round them.
+type bigClassification struct {
+	Number               string   `json:"number"`
+	Digits               int      `json:"digits"`
+	BitLength            int      `json:"bit_length"`
+	IsPrime              bool     `json:"is_prime"` // Miller–Rabin plus Baillie–PSW
+	IsPowerOfTwo         bool     `json:"is_power_of_two"`
+	IsTriangular         bool     `json:"is_triangular"`
+	TriangularIndex      *string  `json:"triangular_index"`
+	IsSquare             bool     `json:"is_square"`
+	SquareIndex          *string  `json:"square_index"`
+	IsEvil               bool     `json:"is_evil"`
+	IsOdious             bool     `json:"is_odious"`
+	Properties           []string `json:"properties"`
+	DigitSum             int      `json:"digit_sum"`
+	Reversed             string   `json:"reversed"`
+	IsPandigital         bool     `json:"is_pandigital"`
+	IsZerolessPandigital bool     `json:"is_zeroless_pandigital"`
+	Sign                 string   `json:"sign"`
+	Undefined            []string `json:"undefined"`
+	IsUndulating         bool     `json:"is_undulating"`
+	IsDisarium           bool     `json:"is_disarium"`
+	IsBinaryPalindrome   bool     `json:"is_binary_palindrome"`
+	Omitted              []string `json:"omitted"` // Classification fields not computed at this size
+}
+
+// precisionQuery reads ?precision=, defaulting to fast.
+func precisionQuery(c *gin.Context) (string, bool) {
+	precision := strings.ToLower(strings.TrimSpace(c.DefaultQuery("precision", precisionFast)))
+	if precision != precisionFast && precision != precisionExact {
+		respondError(c, http.StatusBadRequest, c.Query("precision"), "precision must be fast or exact")
+		return "", false
+	}
+	recordInput(c, "precision", precision)
+	return precision, true
+}
+
+// exactNumberQuery parses "number" like numberQuery, but exactly and without
+// the int64 limit. Strict mode refuses a fraction here too.
+func exactNumberQuery(c *gin.Context) (*big.Int, bool) {
+	if cfg.DefaultNumber != nil && strings.TrimSpace(c.Query("number")) == "" {
+		recordInput(c, "number", *cfg.DefaultNumber)
+		return big.NewInt(int64(*cfg.DefaultNumber)), true
+	}
+	mode, ok := roundingQuery(c)
+	if !ok {
+		return nil, false
+	}
+	raw := c.Query("number")
+	n, rounded, err := parseExactNumber(raw, mode)
+	if err == nil && rounded && strictQuery(c) {
+		err = errNotInteger
+	}
+	if err != nil {
+		if errors.Is(err, errTooLong) {
+			raw = truncateEcho(raw, cfg.MaxNumberLength)
+		}
+		respondError(c, http.StatusBadRequest, raw, err.Error())
+		return nil, false
+	}
+	if n.IsInt64() {
+		recordInput(c, "number", int(n.Int64()))
+	} else {
+		recordInput(c, "number", n.String())
+	}
+	if rounded {
+		recordRounding(c, "number", raw, mode)
+	}
+	return n, true
+}
+
+// parseExactNumber parses a decimal, optionally with a fraction and exponent,
+// rounding a fraction with the given mode with no float rounding
+// ("9007199254740993.9" truncates to 9007199254740993). The result may have
+// up to EXACT_MAX_DIGITS digits. rounded reports whether there was a
+// fraction to drop.
+func parseExactNumber(raw, mode string) (n *big.Int, rounded bool, err error) {
+	if len(raw) > cfg.MaxNumberLength {
+		return nil, false, errTooLong
+	}
+	raw = strings.TrimSpace(raw)
+	m := exactDecimal.FindStringSubmatch(raw)
+	if m == nil || m[2]+m[3] == "" {
+		if f, err := strconv.ParseFloat(raw, 64); err == nil && (math.IsInf(f, 0) || math.IsNaN(f)) {
+			return nil, false, errNotFinite // "Inf", "NaN" and friends
+		}
+		return nil, false, errNotNumeric
+	}
+	sign, whole, frac := m[1], m[2], m[3]
+	if strings.Trim(whole+frac, "0") == "" {
+		return new(big.Int), false, nil // Zero, whatever the exponent
+	}
+	exp := 0
+	if m[4] != "" {
+		e, err := strconv.Atoi(m[4]) // Clamped to the int range on overflow
+		switch {
+		case e < -len(whole+frac):
+			n = roundedFraction(sign == "-", -1, mode) // Below 0.1 in magnitude
+			if sign == "-" {
+				n.Neg(n)
+			}
+			return n, true, nil
+		case err != nil || e > cfg.ExactMaxDigits:
+			return nil, false, errTooManyDigits
+		}
+		exp = e
+	}
+
+	// Shift the decimal point by the exponent, then split off what is left of the fraction
+	digits, dropped, shift := whole+frac, "", exp-len(frac)
+	if shift >= 0 {
+		digits += strings.Repeat("0", shift)
+	} else if -shift >= len(digits) {
+		digits, dropped = "0", strings.Repeat("0", -shift-len(digits))+digits
+	} else {
+		digits, dropped = digits[:len(digits)+shift], digits[len(digits)+shift:]
+	}
+	n, _ = new(big.Int).SetString("0"+digits, 10)
+	if rounded = strings.Trim(dropped, "0") != ""; rounded {
+		half := 1
+		switch {
+		case dropped[0] < '5':
+			half = -1
+		case dropped[0] == '5' && strings.Trim(dropped[1:], "0") == "":
+			half = 0
+		}
+		n.Add(n, roundedFraction(sign == "-", half, mode))
+	}
+	if len(n.String()) > cfg.ExactMaxDigits {
+		return nil, false, errTooManyDigits
+	}
+	if sign == "-" {
+		n.Neg(n)
+	}
+	return n, rounded, nil
+}
+
+// roundedFraction is what rounding adds to the magnitude for a dropped
+// fraction: 1 when the mode rounds it away from zero, else 0. half is as
+// for roundsAway.
+func roundedFraction(negative bool, half int, mode string) *big.Int {
+	if roundsAway(mode, negative, half) {
+		return big.NewInt(1)
+	}
+	return new(big.Int)
+}
+
+// classifyBig computes the properties of n that don't need factoring.
+func classifyBig(n *big.Int) bigClassification {
+	abs := new(big.Int).Abs(n)
+	digits := abs.String()
+	result := bigClassification{
+		Number:     n.String(),
+		Digits:     len(digits),
+		BitLength:  abs.BitLen(),
+		Sign:       signOf(n.Sign()),
+		Properties: []string{},
+		Undefined:  []string{},
+		Omitted:    slices.DeleteFunc(slices.Clone(bigOmittedFields), func(name string) bool { return disabledFields[name] }),
+	}
+
+	if n.Sign() > 0 {
+		result.IsPrime = !disabledProperties["prime"] && n.ProbablyPrime(20)
+		result.IsPowerOfTwo = abs.TrailingZeroBits() == uint(abs.BitLen()-1)
+		if root, ok := bigSquareRoot(n); ok {
+			index := root.String()
+			result.IsSquare, result.SquareIndex = true, &index
+		}
+		// n is triangular with index (√(8n+1) - 1) / 2 when 8n+1 is a square
+		disc := new(big.Int).Lsh(n, 3)
+		if root, ok := bigSquareRoot(disc.Add(disc, big.NewInt(1))); ok {
+			index := root.Rsh(root.Sub(root, big.NewInt(1)), 1).String()
+			result.IsTriangular, result.TriangularIndex = true, &index
+		}
+	} else {
+		result.Undefined = append(result.Undefined, "is_prime")
+	}
+
+	ones := 0
+	for _, word := range abs.Bits() {
+		ones += bits.OnesCount(uint(word))
+	}
+	result.IsEvil, result.IsOdious = ones%2 == 0, ones%2 == 1
+	result.IsBinaryPalindrome = isBigBinaryPalindrome(abs)
+
+	if !disabledProperties["armstrong"] && isBigArmstrong(digits, abs) {
+		result.Properties = append(result.Properties, "armstrong")
+	}
+	if abs.Bit(0) == 0 && !disabledProperties["even"] {
+		result.Properties = append(result.Properties, "even")
+	} else if abs.Bit(0) != 0 && !disabledProperties["odd"] {
+		result.Properties = append(result.Properties, "odd")
+	}
+
+	seen := [10]bool{}
+	reversed := make([]byte, len(digits))
+	for i := range digits {
+		d := digits[i] - '0'
+		result.DigitSum += int(d)
+		seen[d] = true
+		reversed[len(digits)-1-i] = digits[i]
+	}
+	result.IsZerolessPandigital = !slices.Contains(seen[1:], false)
+	result.IsPandigital = result.IsZerolessPandigital && seen[0]
+	result.IsUndulating = undulating(digits)
+	result.IsDisarium = isBigDisarium(digits, abs)
+	result.Reversed = strings.TrimLeft(string(reversed), "0")
+	if n.Sign() < 0 {
+		result.Reversed = "-" + result.Reversed
+	}
+	return result
+}
+
+// bigSquareRoot returns √n when n is a perfect square.
+func bigSquareRoot(n *big.Int) (*big.Int, bool) {
+	root := new(big.Int).Sqrt(n)
+	return root, new(big.Int).Mul(root, root).Cmp(n) == 0
+}
+
+// renderBigClassification writes the exact-mode result for a number outside
+// the int64 range, honoring a field mask over bigClassification's fields.
+func renderBigClassification(c *gin.Context, n *big.Int) {
+	mask, ok := fieldsQuery(c, reflect.TypeOf(bigClassification{}))
+	if !ok {
+		return
+	}
+	if mask == nil {
+		mask = enabledFields(reflect.TypeOf(bigClassification{}))
+	}
+	renderMasked(c, http.StatusOK, classifyBig(n), mask)
+}
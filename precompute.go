@@ -0,0 +1,75 @@
+package main
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// precomputedTable holds the full classifications, fun facts included, of
+// every number from `from` onwards.
+type precomputedTable struct {
+	from    int
+	results []Classification
+}
+
+// precomputed is set once PRECOMPUTE_RANGE has been classified; nil until
+// then or without a range. gRPC may already be serving while it is built.
+var precomputed atomic.Pointer[precomputedTable]
+
+// precomputedResult returns a copy of number's precomputed classification
+// when opts ask for nothing beyond the default one. A field mask is fine:
+// it is applied when the full result is rendered.
+func precomputedResult(number int, opts classifyOptions) (Classification, bool) {
+	table := precomputed.Load()
+	if table == nil || !opts.plain() || number < table.from || number-table.from >= len(table.results) {
+		return Classification{}, false
+	}
+	result := table.results[number-table.from].Clone()
+	if result.FunFactSource == factFromNumbersAPI {
+		result.FunFactSource = factFromCache // Fetched at startup, not for this request
+	}
+	return result, true
+}
+
+// plain reports whether opts request only the default classification. Any
+// option that adds to or changes the result must be listed here.
+func (o classifyOptions) plain() bool {
+	defaultFacts := len(o.FactTypes) == 0 || (len(o.FactTypes) == 1 && o.FactTypes[0] == factTypes[0])
+	return !o.Debug && o.PowerBase == 0 && o.DigitBase == 0 && !o.Explain && !o.Sequences && !o.Verbose && o.FactorFormat == "" &&
+		o.Grouping == nil && o.BitWidth == 0 && !o.Score && !o.Digits && defaultFacts
+}
+
+// precomputeRange classifies every number in [from, to] before the HTTP
+// server starts, fetching fun facts as many at a time as
+// FUN_FACT_MAX_IN_FLIGHT allows, and logs its progress every tenth of the
+// way. A fun fact that can't be fetched keeps its fallback text.
+func precomputeRange(bounds *[2]int) {
+	if bounds == nil {
+		return
+	}
+	from, to := bounds[0], bounds[1]
+	start := time.Now()
+	total := to - from + 1
+	log.Printf("Precomputing classifications for %d to %d (%d numbers)...", from, to, total)
+
+	results := make([]Classification, total)
+	var done atomic.Int64
+	var g errgroup.Group
+	g.SetLimit(max(cfg.FunFactMaxInFlight, 1))
+	for i := range results {
+		g.Go(func() error {
+			results[i] = classify(from+i, classifyOptions{})
+			if n := done.Add(1); n*10/int64(total) != (n-1)*10/int64(total) {
+				log.Printf("Precomputed %d of %d classifications", n, total)
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	precomputed.Store(&precomputedTable{from: from, results: results})
+	log.Printf("Precomputed %d classifications in %s", total, time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// primePage is one page of the primes in a range.
+type primePage struct {
+	Start    int   `json:"start"`
+	End      int   `json:"end"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+	Total    int   `json:"total"`
+	HasMore  bool  `json:"has_more"`
+	Primes   []int `json:"primes"`
+}
+
+// primesInRange lists the primes in [start, end] one page at a time. The first
+// request for a range sieves all of it to count the primes in each segment;
+// with those counts cached, every page sieves only the segments it covers.
+func primesInRange(c *gin.Context) {
+	start, ok := intQuery(c, "start", 0)
+	if !ok {
+		return
+	}
+	end, ok := intQuery(c, "end", 100)
+	if !ok {
+		return
+	}
+	page, ok := intQuery(c, "page", 1)
+	if !ok {
+		return
+	}
+	pageSize, ok := intQuery(c, "page_size", cfg.PrimesDefaultPageSize)
+	if !ok {
+		return
+	}
+
+	// Validate the range and paging parameters
+	switch {
+	case start < 0 || end < start:
+		respondError(c, http.StatusBadRequest, c.Query("end"), "start must be non-negative and end must not be below start")
+		return
+	case end > cfg.PrimesMaxEnd:
+		respondError(c, http.StatusBadRequest, c.Query("end"), fmt.Sprintf("end must be at most %d", cfg.PrimesMaxEnd))
+		return
+	case end-start >= cfg.PrimesMaxRange:
+		respondError(c, http.StatusBadRequest, c.Query("end"), "range exceeds the maximum allowed size")
+		return
+	case page < 1:
+		respondError(c, http.StatusBadRequest, c.Query("page"), "page must be at least 1")
+		return
+	case pageSize < 1 || pageSize > cfg.PrimesMaxPageSize:
+		respondError(c, http.StatusBadRequest, c.Query("page_size"), "page_size out of range")
+		return
+	}
+
+	first := (page - 1) * pageSize // Index of the first prime on this page
+	result := primePage{Start: start, End: end, Page: page, PageSize: pageSize, Primes: []int{}}
+	counts := segmentPrimeCounts(start, end)
+	for _, n := range counts {
+		result.Total += n
+	}
+
+	// Skip whole segments before the page, then sieve until it is full
+	seg, index := 0, 0
+	for seg < len(counts) && index+counts[seg] <= first {
+		index += counts[seg]
+		seg++
+	}
+	if seg < len(counts) {
+		forEachPrime(start+seg*sieveSegmentSize, end, func(p int) bool {
+			if index >= first {
+				result.Primes = append(result.Primes, p)
+			}
+			index++
+			return len(result.Primes) < pageSize
+		})
+	}
+	result.HasMore = first+len(result.Primes) < result.Total
+
+	render(c, http.StatusOK, result)
+}
+
+// primeCountCacheSize caps how many ranges segmentCounts remembers. Each
+// holds one count per segment, so at most PRIMES_MAX_RANGE / 32768 ints.
+const primeCountCacheSize = 256
+
+// rangeCounts caches the primes in each sieveSegmentSize segment of recently
+// paged /api/primes ranges, keyed by [start, end].
+type rangeCounts struct {
+	mu     sync.Mutex
+	counts map[[2]int][]int
+}
+
+// segmentCounts is filled by segmentPrimeCounts.
+var segmentCounts = &rangeCounts{counts: map[[2]int][]int{}}
+
+// get returns the segment counts stored for [start, end].
+func (rc *rangeCounts) get(start, end int) ([]int, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	counts, ok := rc.counts[[2]int{start, end}]
+	return counts, ok
+}
+
+// put stores the segment counts for [start, end], dropping an arbitrary
+// range when full; a dropped range only costs one more full sieve.
+func (rc *rangeCounts) put(start, end int, counts []int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if len(rc.counts) >= primeCountCacheSize {
+		for key := range rc.counts {
+			delete(rc.counts, key)
+			break
+		}
+	}
+	rc.counts[[2]int{start, end}] = counts
+}
+
+// segmentPrimeCounts returns how many primes each sieveSegmentSize segment of
+// [start, end] holds, counting from start, sieving the range only when it
+// isn't cached.
+func segmentPrimeCounts(start, end int) []int {
+	if counts, ok := segmentCounts.get(start, end); ok {
+		return counts
+	}
+	counts := make([]int, (end-start)/sieveSegmentSize+1)
+	forEachPrime(start, end, func(p int) bool {
+		counts[(p-start)/sieveSegmentSize]++
+		return true
+	})
+	segmentCounts.put(start, end, counts)
+	return counts
+}
+
+// primeCount is the body of GET /api/prime-count.
+type primeCount struct {
+	X     int `json:"x"`
+	Count int `json:"count"` // π(x)
+}
+
+// primeCountUpTo serves the prime-counting function π(x), the number of primes
+// <= x, counted with the segmented sieve.
+func primeCountUpTo(c *gin.Context) {
+	x, ok := intQuery(c, "x", 0)
+	if !ok {
+		return
+	}
+	if x < 0 || x > cfg.PrimeCountMaxX {
+		respondError(c, http.StatusBadRequest, c.Query("x"), fmt.Sprintf("x must be between 0 and %d", cfg.PrimeCountMaxX))
+		return
+	}
+
+	result := primeCount{X: x}
+	forEachPrime(2, x, func(int) bool { result.Count++; return true })
+	render(c, http.StatusOK, result)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestPrimesPaging(t *testing.T) {
+	// Wide enough to span several sieve segments, so pages start mid-range
+	const start, end, pageSize = 1000, 200_000, 1000
+	want := []int{}
+	forEachPrime(start, end, func(p int) bool { want = append(want, p); return true })
+
+	var got []int
+	for page := 1; ; page++ {
+		var resp primePage
+		decode(t, get(t, fmt.Sprintf("/api/primes?start=%d&end=%d&page=%d&page_size=%d", start, end, page, pageSize)), &resp)
+		if resp.Total != len(want) {
+			t.Fatalf("page %d: total %d, want %d", page, resp.Total, len(want))
+		}
+		got = append(got, resp.Primes...)
+		if !resp.HasMore {
+			break
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("paged %d primes, want %d", len(got), len(want))
+	}
+
+	// A page past the end is empty but still counts the range
+	var resp primePage
+	decode(t, get(t, fmt.Sprintf("/api/primes?start=%d&end=%d&page=1000&page_size=%d", start, end, pageSize)), &resp)
+	if len(resp.Primes) != 0 || resp.HasMore || resp.Total != len(want) {
+		t.Errorf("past the end: %d primes, has_more %v, total %d", len(resp.Primes), resp.HasMore, resp.Total)
+	}
+}
+
+func TestPrimeCount(t *testing.T) {
+	var resp primeCount
+	decode(t, get(t, "/api/prime-count?x=1000"), &resp)
+	if resp.Count != 168 {
+		t.Errorf("π(1000) = %d, want 168", resp.Count)
+	}
+}
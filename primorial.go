@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// primorialTerm is the body of GET /api/primorial.
+type primorialTerm struct {
+	K            int    `json:"k"`
+	Value        string `json:"value"` // Decimal, as a string so huge primorials stay exact
+	Digits       int    `json:"digits"`
+	LargestPrime *int   `json:"largest_prime"` // p_k; null for k = 0
+}
+
+// primorialOf returns the kth primorial, the product of the first k primes
+// (5# = 2·3·5·7·11 = 2310).
+func primorialOf(k int) (*big.Int, []int) {
+	primes := firstPrimes(k)
+	product := big.NewInt(1)
+	for _, p := range primes {
+		product.Mul(product, big.NewInt(int64(p)))
+	}
+	return product, primes
+}
+
+// primorial serves the kth primorial.
+func primorial(c *gin.Context) {
+	k, ok := intQuery(c, "k", 0)
+	if !ok {
+		return
+	}
+	if k < 0 || k > cfg.PrimorialMaxK {
+		respondError(c, http.StatusBadRequest, c.Query("k"), fmt.Sprintf("k must be between 0 and %d", cfg.PrimorialMaxK))
+		return
+	}
+
+	product, primes := primorialOf(k)
+	value := product.String()
+	result := primorialTerm{K: k, Value: value, Digits: len(value)}
+	if k > 0 {
+		result.LargestPrime = &primes[k-1]
+	}
+	render(c, http.StatusOK, result)
+}
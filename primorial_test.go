@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestPrimorial(t *testing.T) {
+	tests := []struct {
+		k            string
+		value        string
+		largestPrime any
+	}{
+		{"0", "1", nil},
+		{"5", "2310", float64(11)},
+		{"10", "6469693230", float64(29)},
+	}
+	for _, tt := range tests {
+		w := get(t, "/api/primorial?k="+tt.k)
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("k=%s: %d %s", tt.k, w.Code, w.Body)
+		}
+		if body["value"] != tt.value || body["largest_prime"] != tt.largestPrime {
+			t.Errorf("k=%s: value %v, largest_prime %v, want %s, %v", tt.k, body["value"], body["largest_prime"], tt.value, tt.largestPrime)
+		}
+	}
+	for _, k := range []string{"-1", "100000000"} {
+		if w := get(t, "/api/primorial?k="+k); w.Code != http.StatusBadRequest {
+			t.Errorf("k=%s: status %d, want 400", k, w.Code)
+		}
+	}
+}
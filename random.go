@@ -0,0 +1,96 @@
+package main
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// randomResponse is a classification of a randomly picked number.
+type randomResponse struct {
+	Seed *uint64 `json:"seed"` // null when the server picked the seed
+	Min  int     `json:"min"`
+	Max  int     `json:"max"`
+	Classification
+}
+
+// randomNumber picks a number in [min, max] and classifies it. With a seed the
+// pick is deterministic: the same seed, min and max always give the same
+// number on a given release. Stability across releases is not guaranteed.
+// ?fields= masks the response as on /api/classify-number.
+func randomNumber(c *gin.Context) {
+	min, ok := intQuery(c, "min", 1)
+	if !ok {
+		return
+	}
+	max, ok := intQuery(c, "max", 100)
+	if !ok {
+		return
+	}
+	if min > max {
+		respondError(c, http.StatusBadRequest, c.Query("min"), "min must not exceed max")
+		return
+	}
+
+	// Seed PCG from the query when given, otherwise from the auto-seeded global source
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	var seed *uint64
+	if raw, present := c.GetQuery("seed"); present {
+		s, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, raw, "seed must be a non-negative integer")
+			return
+		}
+		seed = &s
+		recordInput(c, "seed", s)
+		rng = rand.New(rand.NewPCG(s, s))
+	}
+
+	// Offset from min using unsigned arithmetic so the full int range works
+	span := uint64(max) - uint64(min)
+	var offset uint64
+	if span == ^uint64(0) {
+		offset = rng.Uint64()
+	} else {
+		offset = rng.Uint64N(span + 1)
+	}
+	number := int(uint64(min) + offset)
+
+	mask, ok := fieldsQuery(c, reflect.TypeOf(randomResponse{}))
+	if !ok {
+		return
+	}
+	mask = degradeMask(c, mask, "seed", "min", "max") // Only cheap fields while overloaded
+	classification, cached := classifyCached(number, classifyOptions{Debug: boolQuery(c, "debug"), Fields: mask})
+	setCacheHeader(c, cached)
+	result := randomResponse{
+		Seed:           seed,
+		Min:            min,
+		Max:            max,
+		Classification: classification,
+	}
+	if mask == nil {
+		mask = enabledFields(reflect.TypeOf(result))
+	}
+	renderMasked(c, http.StatusOK, result, mask)
+}
+
+// intQuery parses an optional integer query param, writing a 400 and
+// returning false when it is present but invalid.
+func intQuery(c *gin.Context, key string, def int) (int, bool) {
+	raw, present := c.GetQuery(key)
+	if !present {
+		recordInput(c, key, def)
+		return def, true
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, raw, key+" must be an integer")
+		return 0, false
+	}
+	recordInput(c, key, n)
+	return n, true
+}
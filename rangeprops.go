@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rangeProperty is one row of GET /api/range-properties: which numbers of the
+// range have the property, as an array or a bitmap.
+type rangeProperty struct {
+	Property string `json:"property"`
+	Count    int    `json:"count"`
+	Values   []int  `json:"values,omitempty"` // Values[i] is 1 if start+i has the property
+	Bitmap   string `json:"bitmap,omitempty"` // Base64; bit i%8 (LSB first) of byte i/8 is start+i
+}
+
+// rangeProperties is the body of GET /api/range-properties.
+type rangeProperties struct {
+	Start      int             `json:"start"`
+	End        int             `json:"end"`
+	Length     int             `json:"length"`
+	Encoding   string          `json:"encoding"`
+	Properties []rangeProperty `json:"properties"`
+}
+
+// rangeMarkers enumerate a property's members in [lo, hi] directly instead of
+// testing every number. Properties without one fall back to the registry check.
+var rangeMarkers = map[string]func(lo, hi int, mark func(int)){
+	"prime": func(lo, hi int, mark func(int)) {
+		forEachPrime(lo, hi, func(p int) bool { mark(p); return true })
+	},
+	"palindrome": func(lo, hi int, mark func(int)) {
+		forEachPalindrome(uint64(lo), uint64(hi), 10, func(p uint64) { mark(int(p)) })
+	},
+	"square": func(lo, hi int, mark func(int)) {
+		k := isqrt(lo)
+		if k*k < lo {
+			k++
+		}
+		for ; k*k <= hi; k++ {
+			mark(k * k)
+		}
+	},
+	"triangular": func(lo, hi int, mark func(int)) {
+		k := max(isqrt(2*lo)-1, 0) // k(k+1)/2 <= lo
+		for t := k * (k + 1) / 2; t <= hi; k, t = k+1, t+k+1 {
+			if t >= lo {
+				mark(t)
+			}
+		}
+	},
+	"power_of_two": func(lo, hi int, mark func(int)) {
+		for p := 1; p <= hi; p <<= 1 {
+			if p >= lo {
+				mark(p)
+			}
+		}
+	},
+}
+
+// propertiesInRange reports, for each requested property, which numbers
+// in [start, end] have it, so a grid or Ulam spiral can be drawn from a
+// single request.
+func propertiesInRange(c *gin.Context) {
+	start, ok := intQuery(c, "start", 1)
+	if !ok {
+		return
+	}
+	end, ok := intQuery(c, "end", 100)
+	if !ok {
+		return
+	}
+	encoding := strings.ToLower(strings.TrimSpace(c.DefaultQuery("encoding", "array")))
+
+	switch {
+	case start < 0 || end < start:
+		respondError(c, http.StatusBadRequest, c.Query("end"), "start must be non-negative and end must not be below start")
+		return
+	case end-start >= cfg.RangePropertiesMaxRange:
+		respondError(c, http.StatusBadRequest, c.Query("end"), "range exceeds the maximum allowed size")
+		return
+	case end > cfg.RangePropertiesMaxEnd:
+		respondError(c, http.StatusBadRequest, c.Query("end"), "end exceeds the maximum allowed value")
+		return
+	case encoding != "array" && encoding != "bitmap":
+		respondError(c, http.StatusBadRequest, c.Query("encoding"), "encoding must be array or bitmap")
+		return
+	}
+	recordInput(c, "encoding", encoding)
+
+	var names []string
+	seen := map[string]bool{}
+	for _, raw := range strings.Split(c.DefaultQuery("property", "prime"), ",") {
+		name, _, ok := resolveProperty(c, raw)
+		if !ok {
+			return
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	recordInput(c, "property", names)
+
+	length := end - start + 1
+	result := rangeProperties{Start: start, End: end, Length: length, Encoding: encoding, Properties: []rangeProperty{}}
+	for _, name := range names {
+		members := make([]bool, length)
+		if marker, ok := rangeMarkers[name]; ok {
+			marker(start, end, func(n int) { members[n-start] = true })
+		} else {
+			check, _ := lookupProperty(name)
+			for i := range members {
+				members[i] = check(start + i)
+			}
+		}
+		result.Properties = append(result.Properties, encodeMembers(name, members, encoding))
+	}
+	render(c, http.StatusOK, result)
+}
+
+// encodeMembers packs one property's membership flags in the given encoding.
+func encodeMembers(name string, members []bool, encoding string) rangeProperty {
+	row := rangeProperty{Property: name}
+	var values []int
+	var bitmap []byte
+	if encoding == "bitmap" {
+		bitmap = make([]byte, (len(members)+7)/8)
+	} else {
+		values = make([]int, len(members))
+	}
+	for i, member := range members {
+		if !member {
+			continue
+		}
+		row.Count++
+		if bitmap != nil {
+			bitmap[i/8] |= 1 << (i % 8)
+		} else {
+			values[i] = 1
+		}
+	}
+	row.Values, row.Bitmap = values, base64.StdEncoding.EncodeToString(bitmap)
+	return row
+}
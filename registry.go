@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// propertyRegistry maps property names to their checks. Endpoints that take a
+// property name (nearest, filter, ...) resolve it here so every supported
+// property works everywhere.
+var propertyRegistry = map[string]func(int) bool{
+	"prime":               isPrime,
+	"perfect":             isPerfect,
+	"armstrong":           isArmstrong,
+	"palindrome":          isPalindrome,
+	"practical":           isPractical,
+	"power_of_two":        isPowerOfTwo,
+	"triangular":          func(n int) bool { _, ok := triangularIndex(n); return ok },
+	"square":              func(n int) bool { _, ok := squareIndex(n); return ok },
+	"evil":                isEvil,
+	"odious":              isOdious,
+	"carmichael":          isCarmichael,
+	"self":                isSelfNumber,
+	"sphenic":             isSphenic,
+	"powerful":            isPowerful,
+	"perfect_power":       isPerfectPower,
+	"achilles":            isAchilles,
+	"undulating":          isUndulating,
+	"circular_prime":      isCircularPrime,
+	"primorial":           isPrimorial,
+	"duffinian":           isDuffinian,
+	"hoax":                isHoax,
+	"keith":               isKeith,
+	"disarium":            isDisarium,
+	"binary_palindrome":   isBinaryPalindrome,
+	"highly_composite":    isHighlyComposite,
+	"pandigital":          func(n int) bool { return isPandigital(n, 10, false) },
+	"zeroless_pandigital": func(n int) bool { return isPandigital(n, 10, true) },
+	"fibonacci":           isFibonacci,
+	"lucas":               isLucas,
+	"frugal":              func(n int) bool { e, _ := digitEconomy(n); return e == economyFrugal },
+	"equidigital":         func(n int) bool { e, _ := digitEconomy(n); return e == economyEquidigital },
+	"extravagant":         func(n int) bool { e, _ := digitEconomy(n); return e == economyExtravagant },
+	"even":                func(n int) bool { return n%2 == 0 },
+	"odd":                 func(n int) bool { return n%2 != 0 },
+}
+
+// factoredProperties are the registry checks that trial-divide n, taking up
+// to √n steps each, while the others look at digits or bits. A search runs
+// its check on thousands of numbers, so for these it refuses numbers past
+// SEARCH_MAX_NUMBER, where a single check already takes milliseconds.
+var factoredProperties = map[string]bool{
+	"prime":          true,
+	"practical":      true,
+	"carmichael":     true,
+	"sphenic":        true,
+	"powerful":       true,
+	"perfect_power":  true,
+	"achilles":       true,
+	"circular_prime": true,
+	"duffinian":      true,
+	"hoax":           true,
+	"frugal":         true,
+	"equidigital":    true,
+	"extravagant":    true,
+}
+
+// propertyAliases maps alternate names from the literature to the registry
+// property they mean. Aliases are accepted wherever a property name is, but
+// responses always use the canonical name. There is deliberately no "parity"
+// alias: it names the even/odd split rather than one property, so it can't
+// resolve to a single check.
+var propertyAliases = map[string]string{
+	"wasteful":       "extravagant",
+	"economical":     "frugal",
+	"narcissistic":   "armstrong",
+	"colombian":      "self",
+	"repfigit":       "keith",
+	"palindromic":    "palindrome",
+	"perfect_square": "square",
+}
+
+// canonicalProperty returns the registry name an alias stands for, or name
+// itself when it isn't an alias.
+func canonicalProperty(name string) string {
+	if canonical, ok := propertyAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// lookupProperty returns the check registered under name or its alias.
+func lookupProperty(name string) (func(int) bool, bool) {
+	check, ok := propertyRegistry[canonicalProperty(name)]
+	return check, ok
+}
+
+// enabledAliases lists the aliases of properties still in the registry.
+func enabledAliases() map[string]string {
+	aliases := map[string]string{}
+	for alias, canonical := range propertyAliases {
+		if _, ok := propertyRegistry[canonical]; ok {
+			aliases[alias] = canonical
+		}
+	}
+	return aliases
+}
+
+// propertyNames lists the registered property names in sorted order.
+func propertyNames() []string {
+	names := make([]string, 0, len(propertyRegistry))
+	for name := range propertyRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveProperty normalizes a client-supplied property name, resolves any
+// alias and looks it up, returning the canonical name. An unknown name gets
+// a 400 that lists the canonical names.
+func resolveProperty(c *gin.Context, raw string) (string, func(int) bool, bool) {
+	name := strings.ToLower(strings.TrimSpace(raw))
+	check, found := lookupProperty(name)
+	if !found {
+		render(c, http.StatusBadRequest, gin.H{
+			"property":         sanitizeEcho(name),
+			"error":            true,
+			"message":          "unknown property",
+			"valid_properties": propertyNames(),
+		})
+		return "", nil, false
+	}
+	recordInput(c, "property", name)
+	return canonicalProperty(name), check, true
+}
+
+// checkSearchBound reports whether a search may test n against the named
+// property, writing a 400 when n is too large for a factored property.
+func checkSearchBound(c *gin.Context, name string, n int) bool {
+	if !factoredProperties[name] || magnitude(n) <= uint64(cfg.SearchMaxNumber) {
+		return true
+	}
+	respondError(c, http.StatusBadRequest, strconv.Itoa(n),
+		fmt.Sprintf("%s is checked by trial division, so searches for it only accept numbers up to %d in magnitude", name, cfg.SearchMaxNumber))
+	return false
+}
+
+// searchContext bounds a search by SEARCH_TIMEOUT and by the client staying
+// connected. Searches check it before every candidate.
+func searchContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Request.Context(), cfg.SearchTimeout)
+}
+
+// respondSearchStopped answers a search its context ended, with a 503 so
+// clients know a retry or a smaller request may succeed.
+func respondSearchStopped(c *gin.Context, err error) {
+	message := "search cancelled"
+	if errors.Is(err, context.DeadlineExceeded) {
+		message = fmt.Sprintf("search did not finish within %s", cfg.SearchTimeout)
+	}
+	render(c, http.StatusServiceUnavailable, gin.H{"error": true, "message": message})
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalProperty(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"wasteful", "extravagant"},
+		{"economical", "frugal"},
+		{"narcissistic", "armstrong"},
+		{"colombian", "self"},
+		{"repfigit", "keith"},
+		{"palindromic", "palindrome"},
+		{"perfect_square", "square"},
+		{"extravagant", "extravagant"},
+		{"nope", "nope"},
+	}
+	for _, tt := range tests {
+		if got := canonicalProperty(tt.name); got != tt.want {
+			t.Errorf("canonicalProperty(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAliasTargetsAreRegistered(t *testing.T) {
+	for alias, canonical := range propertyAliases {
+		if _, ok := propertyRegistry[canonical]; !ok {
+			t.Errorf("alias %q points at unknown property %q", alias, canonical)
+		}
+		if _, ok := propertyRegistry[alias]; ok {
+			t.Errorf("alias %q shadows a registry property", alias)
+		}
+	}
+}
+
+func TestNearestAlias(t *testing.T) {
+	var alias, canonical struct {
+		Property     string
+		Below, Above int
+	}
+	decode(t, get(t, "/api/nearest?number=5&property=wasteful"), &alias)
+	decode(t, get(t, "/api/nearest?number=5&property=extravagant"), &canonical)
+	if alias.Property != "extravagant" {
+		t.Errorf("property = %q, want extravagant", alias.Property)
+	}
+	if alias != canonical {
+		t.Errorf("wasteful = %+v, extravagant = %+v", alias, canonical)
+	}
+}
+
+func TestFilterAlias(t *testing.T) {
+	post := func(property string) filterResponse {
+		w := httptest.NewRecorder()
+		body := `{"numbers": [4, 5, 6, 8, 9, 10, 11, 12], "property": "` + property + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/filter", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		testRouter.ServeHTTP(w, req)
+		var resp filterResponse
+		decode(t, w, &resp)
+		return resp
+	}
+	alias, canonical := post("wasteful"), post("extravagant")
+	if alias.Property != "extravagant" {
+		t.Errorf("property = %q, want extravagant", alias.Property)
+	}
+	if len(alias.Matches) == 0 || !slices.Equal(alias.Matches, canonical.Matches) {
+		t.Errorf("wasteful matches %v, extravagant matches %v", alias.Matches, canonical.Matches)
+	}
+}
+
+func TestScanAlias(t *testing.T) {
+	scan := func(property string) string {
+		w := get(t, "/api/scan?property="+property+"&from=1&limit=5")
+		if w.Code != http.StatusOK {
+			t.Fatalf("scan %s = %d: %s", property, w.Code, w.Body)
+		}
+		return w.Body.String()
+	}
+	alias, canonical := scan("wasteful"), scan("extravagant")
+	if !strings.Contains(alias, "event:match") {
+		t.Fatalf("no matches in %q", alias)
+	}
+	if alias != canonical {
+		t.Errorf("wasteful stream %q, extravagant stream %q", alias, canonical)
+	}
+}
+
+func TestUnknownPropertyListsCanonicalNames(t *testing.T) {
+	w := get(t, "/api/nearest?number=5&property=wastefull")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	var resp struct {
+		ValidProperties []string `json:"valid_properties"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(resp.ValidProperties, "extravagant") {
+		t.Errorf("valid_properties %v lacks extravagant", resp.ValidProperties)
+	}
+	for alias := range propertyAliases {
+		if slices.Contains(resp.ValidProperties, alias) {
+			t.Errorf("valid_properties lists alias %q", alias)
+		}
+	}
+}
+
+// decode unmarshals a successful JSON response into v.
+func decode(t *testing.T, w *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFactoredPropertiesAreRegistered(t *testing.T) {
+	for name := range factoredProperties {
+		if _, ok := propertyRegistry[name]; !ok {
+			t.Errorf("factored property %q is not in the registry", name)
+		}
+		if _, ok := sparseMembers[name]; ok {
+			t.Errorf("factored property %q has a complete list, so it is never searched", name)
+		}
+	}
+}
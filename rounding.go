@@ -0,0 +1,56 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Rounding modes for non-integer input, chosen with ?rounding=.
+const (
+	roundTruncate = "truncate" // Toward zero: 2.7 -> 2, -2.7 -> -2
+	roundNearest  = "nearest"  // Halves away from zero: 2.5 -> 3, -2.5 -> -3
+	roundFloor    = "floor"    // Toward -Inf: -2.5 -> -3
+	roundCeil     = "ceil"     // Toward +Inf: 2.1 -> 3
+)
+
+// roundingFuncs apply each mode to a float.
+var roundingFuncs = map[string]func(float64) float64{
+	roundTruncate: math.Trunc,
+	roundNearest:  math.Round,
+	roundFloor:    math.Floor,
+	roundCeil:     math.Ceil,
+}
+
+// roundingQuery reads ?rounding=, defaulting to truncate.
+func roundingQuery(c *gin.Context) (string, bool) {
+	mode := strings.ToLower(strings.TrimSpace(c.DefaultQuery("rounding", roundTruncate)))
+	if _, ok := roundingFuncs[mode]; !ok {
+		respondError(c, http.StatusBadRequest, c.Query("rounding"), "rounding must be truncate, nearest, floor or ceil")
+		return "", false
+	}
+	return mode, true
+}
+
+// recordRounding echoes how a non-integer input was made an integer, so a
+// client can tell which value was classified.
+func recordRounding(c *gin.Context, key, raw, mode string) {
+	recordInput(c, "rounding", mode)
+	recordInput(c, "original_"+key, strings.TrimSpace(raw))
+}
+
+// roundsAway reports whether a mode moves a value with a dropped fraction
+// away from zero. half compares the fraction with ½: -1 below, 0 equal, 1 above.
+func roundsAway(mode string, negative bool, half int) bool {
+	switch mode {
+	case roundNearest:
+		return half >= 0
+	case roundFloor:
+		return negative
+	case roundCeil:
+		return !negative
+	}
+	return false
+}
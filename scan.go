@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// scanMatch is a "match" event of GET /api/scan.
+type scanMatch struct {
+	Number int `json:"number"`
+}
+
+// scanDone is the terminal "done" event of GET /api/scan.
+type scanDone struct {
+	Found    int    `json:"found"`
+	Scanned  int    `json:"scanned"`   // Numbers checked; 0 when the property has a complete list
+	Reason   string `json:"reason"`    // "limit", "not_found_within_bound" or "timeout"
+	NextFrom *int   `json:"next_from"` // Where to resume; null when nothing is left to search
+}
+
+// scanUpward streams, as server-sent events, the numbers from "from" upward
+// that have the requested property, one "match" event each as soon as it is
+// found. It stops after "limit" matches or max_distance numbers and ends with
+// a "done" event saying which. Sparse properties with a complete list are
+// looked up without a bound. Factored ones only scan from numbers up to
+// SEARCH_MAX_NUMBER, and any scan ends early at SEARCH_TIMEOUT.
+func scanUpward(c *gin.Context) {
+	from, ok := numberParam(c, "from")
+	if !ok {
+		return
+	}
+	name, check, ok := resolveProperty(c, c.Query("property"))
+	if !ok {
+		return
+	}
+	limit, ok := intQuery(c, "limit", 1)
+	if !ok {
+		return
+	}
+	maxDistance, ok := intQuery(c, "max_distance", cfg.ScanMaxDistance)
+	if !ok {
+		return
+	}
+	switch {
+	case limit < 1 || limit > cfg.ScanMaxLimit:
+		respondError(c, http.StatusBadRequest, c.Query("limit"), fmt.Sprintf("limit must be between 1 and %d", cfg.ScanMaxLimit))
+		return
+	case maxDistance < 1 || maxDistance > cfg.ScanMaxDistance:
+		respondError(c, http.StatusBadRequest, c.Query("max_distance"), fmt.Sprintf("max_distance must be between 1 and %d", cfg.ScanMaxDistance))
+		return
+	}
+	if _, sparse := sparseMembers[name]; !sparse && !checkSearchBound(c, name, from) {
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // Keep proxies from holding matches back
+	ctx, cancel := searchContext(c)
+	defer cancel()
+	done := scanDone{Reason: "not_found_within_bound"}
+	emit := func(n int) {
+		c.SSEvent("match", scanMatch{Number: n})
+		c.Writer.Flush()
+		done.Found++
+	}
+
+	if members, ok := sparseMembers[name]; ok {
+		i, _ := slices.BinarySearch(members, from)
+		for ; i < len(members) && done.Found < limit; i++ {
+			emit(members[i])
+		}
+		if i < len(members) {
+			next := members[i]
+			done.NextFrom = &next
+		}
+	} else {
+		last := math.MaxInt
+		if from <= math.MaxInt-(maxDistance-1) {
+			last = from + maxDistance - 1
+		}
+		for n := from; ; n++ {
+			if ctx.Err() != nil {
+				if c.Request.Context().Err() != nil {
+					return // Client went away; nothing more can be sent
+				}
+				done.Reason, done.NextFrom = "timeout", &n
+				c.SSEvent("done", done)
+				return
+			}
+			done.Scanned++
+			if check(n) {
+				emit(n)
+			}
+			if done.Found == limit || n == last {
+				if n < math.MaxInt {
+					next := n + 1
+					done.NextFrom = &next
+				}
+				break
+			}
+		}
+	}
+	if done.Found == limit {
+		done.Reason = "limit"
+	}
+	c.SSEvent("done", done)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scanDoneEvent returns the data of the stream's final done event.
+func scanDoneEvent(t *testing.T, stream string) scanDone {
+	t.Helper()
+	_, data, ok := strings.Cut(stream, "event:done\ndata:")
+	if !ok {
+		t.Fatalf("no done event in %q", stream)
+	}
+	var done scanDone
+	if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &done); err != nil {
+		t.Fatalf("%q: %v", data, err)
+	}
+	return done
+}
+
+func TestScanSearchBound(t *testing.T) {
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{"from=1000000000000000000&property=carmichael", http.StatusBadRequest},
+		{"from=-1000000000000000000&property=prime", http.StatusBadRequest},
+		{"from=1000000000000&property=prime&limit=1", http.StatusOK},
+		{"from=1000000000000000000&property=perfect", http.StatusOK},    // A list lookup
+		{"from=1000000000000000000&property=palindrome", http.StatusOK}, // A digit check
+	}
+	for _, tt := range tests {
+		start := time.Now()
+		w := get(t, "/api/scan?"+tt.query)
+		if w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.query, w.Code, tt.status, w.Body)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("%s took %v", tt.query, elapsed)
+		}
+	}
+}
+
+func TestScanTimeout(t *testing.T) {
+	defer func(d time.Duration) { cfg.SearchTimeout = d }(cfg.SearchTimeout)
+	cfg.SearchTimeout = time.Nanosecond
+
+	w := get(t, "/api/scan?from=100&property=prime")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	done := scanDoneEvent(t, w.Body.String())
+	if done.Reason != "timeout" || done.NextFrom == nil || *done.NextFrom != 100+done.Scanned {
+		t.Errorf("done %+v, want a timeout resuming after the %d scanned", done, done.Scanned)
+	}
+}
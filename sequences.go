@@ -0,0 +1,65 @@
+package main
+
+import "github.com/adidazbot/num_class_api/numclass"
+
+// sequence describes the integer sequence behind a registry property.
+type sequence = numclass.Sequence
+
+// oeis is a helper for taking the address of an A-number literal.
+func oeis(id string) *string { return &id }
+
+// propertySequences labels each registry property with its sequence.
+var propertySequences = map[string]sequence{
+	"prime":               {Name: "Prime numbers", OEIS: oeis("A000040"), Description: "Divisible only by 1 and themselves"},
+	"perfect":             {Name: "Perfect numbers", OEIS: oeis("A000396"), Description: "Equal to the sum of their proper divisors"},
+	"armstrong":           {Name: "Armstrong (narcissistic) numbers", OEIS: oeis("A005188"), Description: "Equal to the sum of their digits each raised to the number of digits"},
+	"palindrome":          {Name: "Palindromes", OEIS: oeis("A002113"), Description: "Read the same forwards and backwards in base 10"},
+	"practical":           {Name: "Practical numbers", OEIS: oeis("A005153"), Description: "Every smaller positive integer is a sum of distinct divisors"},
+	"power_of_two":        {Name: "Powers of 2", OEIS: oeis("A000079"), Description: "1, 2, 4, 8, ..."},
+	"triangular":          {Name: "Triangular numbers", OEIS: oeis("A000217"), Description: "k(k+1)/2, the sum of the first k integers"},
+	"square":              {Name: "Squares", OEIS: oeis("A000290"), Description: "k² for a whole number k"},
+	"evil":                {Name: "Evil numbers", OEIS: oeis("A001969"), Description: "An even number of 1s in binary"},
+	"odious":              {Name: "Odious numbers", OEIS: oeis("A000069"), Description: "An odd number of 1s in binary"},
+	"carmichael":          {Name: "Carmichael numbers", OEIS: oeis("A002997"), Description: "Composites that pass the Fermat test for every coprime base"},
+	"self":                {Name: "Self (Colombian) numbers", OEIS: oeis("A003052"), Description: "Not m plus the digit sum of m for any m"},
+	"sphenic":             {Name: "Sphenic numbers", OEIS: oeis("A007304"), Description: "Products of three distinct primes"},
+	"powerful":            {Name: "Powerful numbers", OEIS: oeis("A001694"), Description: "Every prime factor appears at least squared"},
+	"perfect_power":       {Name: "Perfect powers", OEIS: oeis("A001597"), Description: "m^k for whole numbers m and k >= 2"},
+	"achilles":            {Name: "Achilles numbers", OEIS: oeis("A052486"), Description: "Powerful but not perfect powers"},
+	"undulating":          {Name: "Undulating numbers", OEIS: oeis("A046075"), Description: "At least three digits, alternating between two distinct values"},
+	"circular_prime":      {Name: "Circular primes", OEIS: oeis("A068652"), Description: "Prime under every rotation of their digits"},
+	"primorial":           {Name: "Primorials", OEIS: oeis("A002110"), Description: "Products of the first k primes"},
+	"duffinian":           {Name: "Duffinian numbers", OEIS: oeis("A003624"), Description: "Composites coprime to the sum of their divisors"},
+	"hoax":                {Name: "Hoax numbers", OEIS: oeis("A019506"), Description: "Composites whose digit sum equals that of their distinct prime factors"},
+	"keith":               {Name: "Keith numbers", OEIS: oeis("A007629"), Description: "Appear in the Fibonacci-like sequence seeded by their own digits"},
+	"disarium":            {Name: "Disarium numbers", OEIS: oeis("A032799"), Description: "Equal to the sum of their digits each raised to its position"},
+	"binary_palindrome":   {Name: "Binary palindromes", OEIS: oeis("A006995"), Description: "Read the same forwards and backwards in base 2"},
+	"highly_composite":    {Name: "Highly composite numbers", OEIS: oeis("A002182"), Description: "Have more divisors than any smaller positive integer"},
+	"pandigital":          {Name: "Pandigital numbers", OEIS: oeis("A171102"), Description: "Contain every digit 0-9"},
+	"zeroless_pandigital": {Name: "Zeroless pandigital numbers", Description: "Contain every digit 1-9"},
+	"fibonacci":           {Name: "Fibonacci numbers", OEIS: oeis("A000045"), Description: "Each term is the sum of the two before it, starting 0, 1"},
+	"lucas":               {Name: "Lucas numbers", OEIS: oeis("A000032"), Description: "Each term is the sum of the two before it, starting 2, 1"},
+	"frugal":              {Name: "Frugal (economical) numbers", OEIS: oeis("A046759"), Description: "Fewer digits in their prime factorization than in themselves"},
+	"equidigital":         {Name: "Equidigital numbers", OEIS: oeis("A046758"), Description: "As many digits in their prime factorization as in themselves"},
+	"extravagant":         {Name: "Extravagant (wasteful) numbers", OEIS: oeis("A046760"), Description: "More digits in their prime factorization than in themselves"},
+	"even":                {Name: "Even numbers", OEIS: oeis("A005843"), Description: "Divisible by 2"},
+	"odd":                 {Name: "Odd numbers", OEIS: oeis("A005408"), Description: "Not divisible by 2"},
+}
+
+// sequencesOf lists the labeled sequences a number belongs to, in property
+// name order. Properties without a label are still listed by name.
+func sequencesOf(n int) []sequence {
+	memberships := []sequence{}
+	for _, name := range propertyNames() {
+		if !propertyRegistry[name](n) {
+			continue
+		}
+		s, ok := propertySequences[name]
+		if !ok {
+			s = sequence{Name: name}
+		}
+		s.Property = name
+		memberships = append(memberships, s)
+	}
+	return memberships
+}
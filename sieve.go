@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math"
+	"math/bits"
+)
+
+// sieveSegmentSize is the width of each window processed by forEachPrime.
+const sieveSegmentSize = 1 << 15
+
+// primesUpTo returns all primes <= limit using the sieve of Eratosthenes.
+func primesUpTo(limit int) []int {
+	if limit < 2 {
+		return []int{}
+	}
+	composite := make([]bool, limit+1)
+	primes := []int{}
+	for i := 2; i <= limit; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, i)
+		for j := i * i; j <= limit; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// firstPrimes returns the first k primes, sieving up to Rosser's bound
+// p_k < k(ln k + ln ln k), which holds for k >= 6.
+func firstPrimes(k int) []int {
+	limit := 13 // p_6
+	if k >= 6 {
+		n := float64(k)
+		limit = int(n * (math.Log(n) + math.Log(math.Log(n))))
+	}
+	return primesUpTo(limit)[:k]
+}
+
+// forEachPrime calls fn for every prime in [lo, hi] in ascending order using a
+// segmented sieve, so memory stays bounded by the segment size. Iteration
+// stops early if fn returns false.
+func forEachPrime(lo, hi int, fn func(p int) bool) {
+	if lo < 2 {
+		lo = 2
+	}
+	if hi < lo {
+		return
+	}
+
+	base := primesUpTo(isqrt(hi))
+	composite := make([]bool, sieveSegmentSize)
+	for segLo := lo; segLo <= hi; segLo += sieveSegmentSize {
+		segHi := segLo + sieveSegmentSize - 1
+		if segHi > hi || segHi < segLo { // Clamp, including on overflow
+			segHi = hi
+		}
+		width := segHi - segLo + 1
+		for i := 0; i < width; i++ {
+			composite[i] = false
+		}
+
+		// Cross off multiples of each base prime, starting no lower than p*p
+		for _, p := range base {
+			if p > segHi/p {
+				break
+			}
+			start := segLo / p * p
+			if start < segLo {
+				start += p
+			}
+			if start < p*p {
+				start = p * p
+			}
+			for m := start; m <= segHi && m >= start; m += p {
+				composite[m-segLo] = true
+			}
+		}
+
+		for i := 0; i < width; i++ {
+			if !composite[i] && !fn(segLo+i) {
+				return
+			}
+		}
+		if segHi == hi {
+			return
+		}
+	}
+}
+
+// isqrt returns the largest integer r with r*r <= n, or 0 for n < 1. It
+// uses Newton's method on integers, with no float rounding: from a start
+// at or above √n, x = (x + n/x) / 2 falls until it reaches ⌊√n⌋. Every
+// integer square root in the API goes through here, since
+// int(math.Sqrt(float64(n))) can be off by one above 2^52.
+func isqrt(n int) int {
+	if n < 1 {
+		return 0
+	}
+	x := 1 << ((bits.Len(uint(n)) + 1) / 2) // 2^⌈bits/2⌉ >= √n, and at most 2^32
+	for {
+		y := (x + n/x) / 2
+		if y >= x {
+			return x
+		}
+		x = y
+	}
+}
+
+// iroot returns the largest integer r with r^k <= n, or 0 for n < 1.
+func iroot(n, k int) int {
+	if n < 1 {
+		return 0
+	}
+	// Start from the float estimate and correct for rounding in either direction
+	r := int(math.Pow(float64(n), 1/float64(k)))
+	for r > 1 && powerExceeds(r, k, n) {
+		r--
+	}
+	for !powerExceeds(r+1, k, n) {
+		r++
+	}
+	return r
+}
+
+// powerExceeds reports whether r^k > n for r >= 1 without overflowing.
+func powerExceeds(r, k, n int) bool {
+	p := 1
+	for i := 0; i < k; i++ {
+		if p > n/r {
+			return true
+		}
+		p *= r
+	}
+	return false
+}
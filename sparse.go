@@ -0,0 +1,173 @@
+package main
+
+import (
+	"cmp"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// perfectNumbers lists every perfect number that fits in an int.
+var perfectNumbers = euclidEulerPerfects()
+
+// primorialNumbers lists every primorial that fits in an int, from 0# = 1.
+var primorialNumbers = smallPrimorials()
+
+// highlyCompositeNumbers lists every highly composite number that fits in an
+// int, from 1.
+var highlyCompositeNumbers = divisorRecords()
+
+// armstrongNumbers lists every non-negative base-10 Armstrong number that fits
+// in an int (OEIS A005188 up to 19 digits). There are only 88 in all, the
+// largest with 39 digits, so the list is fixed rather than searched for.
+var armstrongNumbers = []int{
+	0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+	153, 370, 371, 407, 1634, 8208, 9474, 54748, 92727, 93084, 548834,
+	1741725, 4210818, 9800817, 9926315, 24678050, 24678051, 88593477,
+	146511208, 472335975, 534494836, 912985153, 4679307774,
+	32164049650, 32164049651, 40028394225, 42678290603, 44708635679,
+	49388550606, 82693916578, 94204591914, 28116440335967,
+	4338281769391370, 4338281769391371, 21897142587612075,
+	35641594208964132, 35875699062250035, 1517841543307505039,
+	3289582984443187032, 4498128791164624869, 4929273885928088826,
+}
+
+// sparseMembers holds the complete, sorted members of each sparse property
+// across the whole int range, negatives included, for O(log n) lookups.
+var sparseMembers = map[string][]int{
+	"perfect":          perfectNumbers,
+	"armstrong":        mirrored(armstrongNumbers), // Armstrong checks use the magnitude
+	"primorial":        primorialNumbers,
+	"highly_composite": highlyCompositeNumbers,
+}
+
+// sparseList is the body of GET /api/list/:property.
+type sparseList struct {
+	Property string `json:"property"`
+	Count    int    `json:"count"`
+	Numbers  []int  `json:"numbers"`
+}
+
+// euclidEulerPerfects builds the perfect numbers 2^(p-1)(2^p - 1) for each
+// prime 2^p - 1 while the product fits in an int. Every even perfect number
+// has this form, and no odd one exists below 10^1500.
+func euclidEulerPerfects() []int {
+	var perfects []int
+	for p := 2; p <= 32; p++ {
+		if mersenne := 1<<p - 1; isPrime(mersenne) {
+			perfects = append(perfects, 1<<(p-1)*mersenne)
+		}
+	}
+	return perfects
+}
+
+// smallPrimorials builds the products of the first k primes while they fit
+// in an int, which stops at 47# (15 primes).
+func smallPrimorials() []int {
+	primorials := []int{1}
+	for _, p := range firstPrimes(20) {
+		last := primorials[len(primorials)-1]
+		if last > math.MaxInt/p {
+			break
+		}
+		primorials = append(primorials, last*p)
+	}
+	return primorials
+}
+
+// divisorRecords builds the highly composite numbers, those with more
+// divisors than any smaller positive integer. Each is 2^a·3^b·5^c··· with
+// a >= b >= c >= ..., so only those products are candidates, and sorted by
+// size the records among them are the answer. There are 43,607
+// candidates below 2^63.
+func divisorRecords() []int {
+	type candidate struct{ n, divisors int }
+	var candidates []candidate
+	primes := firstPrimes(15) // 47# is the last primorial that fits
+	var walk func(n, maxExponent int, factors []primeFactor)
+	walk = func(n, maxExponent int, factors []primeFactor) {
+		candidates = append(candidates, candidate{n, divisorCount(factors)})
+		if len(factors) == len(primes) {
+			return
+		}
+		p := primes[len(factors)]
+		for e := 1; e <= maxExponent && n <= math.MaxInt/p; e++ {
+			n *= p
+			walk(n, e, append(factors[:len(factors):len(factors)], primeFactor{Prime: p, Exponent: e}))
+		}
+	}
+	walk(1, 62, nil)
+	slices.SortFunc(candidates, func(a, b candidate) int { return cmp.Compare(a.n, b.n) })
+
+	var records []int
+	best := 0
+	for _, c := range candidates {
+		if c.divisors > best {
+			records, best = append(records, c.n), c.divisors
+		}
+	}
+	return records
+}
+
+// mirrored returns the sorted union of members and their negations.
+func mirrored(members []int) []int {
+	out := make([]int, 0, 2*len(members))
+	for i := len(members) - 1; i >= 0; i-- {
+		if members[i] != 0 {
+			out = append(out, -members[i])
+		}
+	}
+	return append(out, members...)
+}
+
+// isSparseMember reports whether n is in members by binary search.
+func isSparseMember(members []int, n int) bool {
+	_, found := slices.BinarySearch(members, n)
+	return found
+}
+
+// nearestMember returns the members closest to n strictly below and above it,
+// or nil when there is none on that side.
+func nearestMember(members []int, n int) (below, above *int) {
+	i, found := slices.BinarySearch(members, n)
+	if i > 0 {
+		below = &members[i-1]
+	}
+	if found {
+		i++
+	}
+	if i < len(members) {
+		above = &members[i]
+	}
+	return below, above
+}
+
+// listSparse serves the complete list of perfect, Armstrong, primorial or
+// highly composite numbers.
+func listSparse(c *gin.Context) {
+	name := canonicalProperty(strings.ToLower(c.Param("property")))
+	lists := map[string][]int{
+		"armstrong":        armstrongNumbers,
+		"perfect":          perfectNumbers,
+		"primorial":        primorialNumbers,
+		"highly_composite": highlyCompositeNumbers,
+	}
+	for property := range disabledProperties {
+		delete(lists, property)
+	}
+	numbers, ok := lists[name]
+	if !ok {
+		render(c, http.StatusNotFound, gin.H{
+			"error":     true,
+			"message":   "no precomputed list for this property",
+			"property":  sanitizeEcho(name),
+			"available": sortedKeys(lists),
+		})
+		return
+	}
+	recordInput(c, "property", name)
+	render(c, http.StatusOK, sparseList{Property: name, Count: len(numbers), Numbers: numbers})
+}
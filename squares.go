@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// twoSquaresResult is the body of GET /api/sum-of-two-squares.
+type twoSquaresResult struct {
+	Number            int   `json:"number"`
+	IsSumOfTwoSquares bool  `json:"is_sum_of_two_squares"`
+	Pair              []int `json:"pair"` // The canonical [a, b], smallest a first; null when none exists
+}
+
+// sumOfTwoSquares reports whether the number can be written as a² + b² and,
+// if so, returns the canonical pair: of all [a, b] with a <= b, the one with
+// the smallest a, so 50 is [1, 7] and never [5, 5].
+func sumOfTwoSquares(c *gin.Context) {
+	number, ok := numberQuery(c)
+	if !ok {
+		return
+	}
+
+	pair := twoSquares(number)
+	render(c, http.StatusOK, twoSquaresResult{
+		Number:            number,
+		IsSumOfTwoSquares: pair != nil,
+		Pair:              pair,
+	})
+}
+
+// isSumOfTwoSquares applies Fermat's theorem: n >= 0 is a sum of two squares
+// iff every prime p ≡ 3 (mod 4) appears in its factorization to an even power.
+func isSumOfTwoSquares(n int) bool {
+	if n < 0 {
+		return false
+	}
+	for _, f := range factorize(n) {
+		if f.Prime%4 == 3 && f.Exponent%2 != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// twoSquares returns [a, b] with a <= b and a² + b² = n, or nil if none exists.
+func twoSquares(n int) []int {
+	if !isSumOfTwoSquares(n) {
+		return nil
+	}
+	// Search a upward so the pair with the smallest a is returned; b follows from a
+	for a, limit := 0, isqrt(n/2); a <= limit; a++ {
+		rest := n - a*a
+		if b := isqrt(rest); b*b == rest {
+			return []int{a, b}
+		}
+	}
+	return nil
+}
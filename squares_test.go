@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestSumOfTwoSquares(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{25, []int{0, 5}}, // Not [3, 4]
+		{50, []int{1, 7}}, // Not [5, 5]
+		{65, []int{1, 8}}, // Not [4, 7]
+		{0, []int{0, 0}},
+		{3, nil},
+		{21, nil}, // 3 × 7
+		{-1, nil},
+	}
+	for _, tt := range tests {
+		// Repeat each request, since the pair must not depend on timing or caching
+		for range 2 {
+			w := get(t, "/api/sum-of-two-squares?number="+strconv.Itoa(tt.n))
+			var body twoSquaresResult
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || w.Code != http.StatusOK {
+				t.Fatalf("%d: %d %s", tt.n, w.Code, w.Body)
+			}
+			if !slices.Equal(body.Pair, tt.want) || body.IsSumOfTwoSquares != (tt.want != nil) {
+				t.Errorf("%d: pair %v, is_sum_of_two_squares %v, want %v", tt.n, body.Pair, body.IsSumOfTwoSquares, tt.want)
+			}
+		}
+	}
+}
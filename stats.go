@@ -0,0 +1,150 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statsTopNumbers is how many exact numbers /api/stats reports when enabled.
+const statsTopNumbers = 10
+
+// statsMaxExactValues bounds the exact-value table so it can't grow forever.
+const statsMaxExactValues = 10000
+
+// classificationStats aggregates what has been classified since startup.
+type classificationStats struct {
+	total      atomic.Uint64
+	properties map[string]*atomic.Uint64 // Fixed at startup, so reads need no lock
+
+	mu    sync.Mutex
+	exact map[int]uint64 // Only populated when cfg.StatsExactValues is set
+}
+
+// stats is the process-wide classification tally.
+var stats = newClassificationStats()
+
+// newClassificationStats creates counters for every reported property.
+func newClassificationStats() *classificationStats {
+	s := &classificationStats{properties: map[string]*atomic.Uint64{}, exact: map[int]uint64{}}
+	for _, name := range []string{"prime", "perfect", "practical", "armstrong", "even", "odd"} {
+		s.properties[name] = new(atomic.Uint64)
+	}
+	return s
+}
+
+// record counts a classification, subject to the configured sample rate.
+func (s *classificationStats) record(result Classification) {
+	if cfg.StatsSampleRate < 1 && rand.Float64() >= cfg.StatsSampleRate {
+		return
+	}
+
+	s.total.Add(1)
+	classifiedMagnitude.Observe(math.Abs(float64(result.Number)))
+
+	names := append([]string{}, result.Properties...)
+	if result.IsPrime {
+		names = append(names, "prime")
+	}
+	if result.IsPerfect {
+		names = append(names, "perfect")
+	}
+	if result.IsPractical {
+		names = append(names, "practical")
+	}
+	for _, name := range names {
+		if counter, ok := s.properties[name]; ok {
+			counter.Add(1)
+		}
+	}
+
+	if cfg.StatsExactValues {
+		s.mu.Lock()
+		if _, seen := s.exact[result.Number]; seen || len(s.exact) < statsMaxExactValues {
+			s.exact[result.Number]++
+		}
+		s.mu.Unlock()
+	}
+}
+
+// numberCount is one entry of the most-classified numbers list.
+type numberCount struct {
+	Number int    `json:"number"`
+	Count  uint64 `json:"count"`
+}
+
+// statsResponse is the body of GET /api/stats.
+type statsResponse struct {
+	TotalClassifications uint64            `json:"total_classifications"`
+	SampleRate           float64           `json:"sample_rate"`
+	PercentPrime         float64           `json:"percent_prime"`
+	PercentPerfect       float64           `json:"percent_perfect"`
+	MostCommonProperty   *string           `json:"most_common_property"` // null before any classification
+	PropertyCounts       map[string]uint64 `json:"property_counts"`
+	TopNumbers           []numberCount     `json:"top_numbers,omitempty"` // Only with STATS_EXACT_VALUES
+}
+
+// snapshot summarizes the counters.
+func (s *classificationStats) snapshot() statsResponse {
+	resp := statsResponse{
+		TotalClassifications: s.total.Load(),
+		SampleRate:           cfg.StatsSampleRate,
+		PropertyCounts:       map[string]uint64{},
+	}
+
+	var best uint64
+	for _, name := range sortedKeys(s.properties) {
+		count := s.properties[name].Load()
+		resp.PropertyCounts[name] = count
+		if count > best {
+			best = count
+			n := name
+			resp.MostCommonProperty = &n
+		}
+	}
+
+	if resp.TotalClassifications > 0 {
+		total := float64(resp.TotalClassifications)
+		resp.PercentPrime = 100 * float64(resp.PropertyCounts["prime"]) / total
+		resp.PercentPerfect = 100 * float64(resp.PropertyCounts["perfect"]) / total
+	}
+
+	if cfg.StatsExactValues {
+		s.mu.Lock()
+		resp.TopNumbers = make([]numberCount, 0, len(s.exact))
+		for number, count := range s.exact {
+			resp.TopNumbers = append(resp.TopNumbers, numberCount{Number: number, Count: count})
+		}
+		s.mu.Unlock()
+		sort.Slice(resp.TopNumbers, func(i, j int) bool {
+			if resp.TopNumbers[i].Count != resp.TopNumbers[j].Count {
+				return resp.TopNumbers[i].Count > resp.TopNumbers[j].Count
+			}
+			return resp.TopNumbers[i].Number < resp.TopNumbers[j].Number
+		})
+		if len(resp.TopNumbers) > statsTopNumbers {
+			resp.TopNumbers = resp.TopNumbers[:statsTopNumbers]
+		}
+	}
+	return resp
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// classificationStatsHandler reports aggregate classification counts.
+func classificationStatsHandler(c *gin.Context) {
+	render(c, http.StatusOK, stats.snapshot())
+}
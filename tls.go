@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// certReloadInterval is how often the certificate files are checked for changes.
+const certReloadInterval = time.Minute
+
+// certReloader serves a certificate from disk and reloads it when the files
+// change, so renewed certificates are picked up without a restart.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// newCertReloader loads the certificate once, failing fast if it is invalid.
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// resolveCertFiles picks the certificate and key from the config: explicit
+// files win, otherwise a directory with Kubernetes (tls.crt/tls.key) or
+// Let's Encrypt (fullchain.pem/privkey.pem) names is searched.
+func resolveCertFiles(certFile, keyFile, dir string) (string, string, error) {
+	if certFile != "" && keyFile != "" {
+		return certFile, keyFile, nil
+	}
+	if dir == "" {
+		return "", "", errors.New("no TLS certificate configured")
+	}
+	for _, names := range [][2]string{{"tls.crt", "tls.key"}, {"fullchain.pem", "privkey.pem"}} {
+		cert, key := filepath.Join(dir, names[0]), filepath.Join(dir, names[1])
+		if fileExists(cert) && fileExists(key) {
+			return cert, key, nil
+		}
+	}
+	return "", "", errors.New("no certificate pair found in " + dir)
+}
+
+// fileExists reports whether path names an existing file.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// reload reads the certificate pair from disk.
+func (r *certReloader) reload() error {
+	info, err := os.Stat(r.certFile)
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	r.cert, r.modTime = &cert, info.ModTime()
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate, reloading the pair
+// when the certificate file's modification time changes.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) >= certReloadInterval {
+		r.checkedAt = time.Now()
+		if info, err := os.Stat(r.certFile); err == nil && !info.ModTime().Equal(r.modTime) {
+			if err := r.reload(); err != nil {
+				log.Printf("Keeping previous TLS certificate, reload failed: %v", err) // e.g. mid-renewal
+			} else {
+				log.Printf("Reloaded TLS certificate from %s", r.certFile)
+			}
+		}
+	}
+	return r.cert, nil
+}
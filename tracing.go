@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTLP transports, as OTEL_EXPORTER_OTLP_PROTOCOL names them.
+const (
+	otlpHTTP = "http/protobuf"
+	otlpGRPC = "grpc"
+)
+
+// tracer starts the server's spans. Until startTracing installs a provider
+// it is OpenTelemetry's no-op tracer, and spans cost next to nothing.
+var tracer = otel.Tracer("github.com/adidazbot/num_class_api")
+
+// startTracing exports spans over OTLP when OTEL_EXPORTER_OTLP_ENDPOINT (or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) is set, and reads and writes W3C
+// traceparent headers. The exporter takes its other settings, such as
+// OTEL_EXPORTER_OTLP_HEADERS, from the standard variables, and the service
+// name defaults to num_class_api unless OTEL_SERVICE_NAME is set. The
+// returned function flushes pending spans on shutdown.
+func startTracing(ctx context.Context) (func(context.Context) error, error) {
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	var exporter sdktrace.SpanExporter
+	var err error
+	if cfg.OTLPProtocol == otlpGRPC {
+		exporter, err = otlptracegrpc.New(ctx)
+	} else {
+		exporter, err = otlptracehttp.New(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName("num_class_api")),
+		resource.WithFromEnv(), // OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES win
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	log.Printf("Tracing enabled: exporting spans over OTLP (%s) to %s", cfg.OTLPProtocol, cfg.OTLPEndpoint)
+	return provider.Shutdown, nil
+}
+
+// traceRequests wraps each API request in a server span named after its
+// route, continuing any trace the caller's traceparent header started. The
+// span's context replaces the request's, so spans started while handling it,
+// like the outbound fun-fact call, become its children.
+func traceRequests() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		route := c.FullPath() // The pattern, not the raw path, so span names stay few
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(c.Request.Method), semconv.HTTPRoute(route)),
+		)
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// startUpstreamSpan starts a client span for a Numbers API request and
+// writes its traceparent into the request's headers.
+func startUpstreamSpan(req *http.Request) (*http.Request, trace.Span) {
+	ctx, span := tracer.Start(req.Context(), "numbersapi "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(req.Method), semconv.URLFull(req.URL.String())),
+	)
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req, span
+}
+
+// endUpstreamSpan records how a Numbers API request went and ends its span.
+func endUpstreamSpan(span trace.Span, status int, outcome string, err error) {
+	if status != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	}
+	span.SetAttributes(attribute.String("numclass.upstream.outcome", outcome))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, fmt.Sprintf("%s: %v", outcome, err))
+	}
+	span.End()
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// untouchableResult is the body of GET /api/untouchable.
+type untouchableResult struct {
+	Number        int    `json:"number"`
+	IsUntouchable bool   `json:"is_untouchable"`
+	Exact         bool   `json:"exact"`          // false when only verified up to the bound
+	VerifiedUpTo  int    `json:"verified_up_to"` // Largest m whose aliquot sum was checked
+	Witness       *int   `json:"witness"`        // The smallest m with aliquot sum equal to number, else null
+	Result        string `json:"result"`
+}
+
+// untouchable checks whether a number is the aliquot sum of some m <= bound.
+// A composite m has aliquot sum at least 1 + √m, so for n >= 2 only
+// m <= (n-1)² can be witnesses; a bound that reaches it gives a proof.
+func untouchable(c *gin.Context) {
+	number, ok := numberQuery(c)
+	if !ok {
+		return
+	}
+	if number < 0 {
+		respondError(c, http.StatusBadRequest, c.Query("number"), "number must not be negative")
+		return
+	}
+	bound, ok := intQuery(c, "bound", cfg.UntouchableMaxBound)
+	if !ok {
+		return
+	}
+	if bound < 1 || bound > cfg.UntouchableMaxBound {
+		respondError(c, http.StatusBadRequest, c.Query("bound"), fmt.Sprintf("bound must be between 1 and %d", cfg.UntouchableMaxBound))
+		return
+	}
+
+	// Stop at (n-1)² when it is within the bound: past it the answer is settled
+	exact := false
+	if n1 := number - 1; number >= 2 && n1 <= bound/n1 {
+		bound, exact = max(n1*n1, 1), true
+	}
+
+	result := untouchableResult{Number: number, VerifiedUpTo: bound}
+	for m, sum := range aliquotSumsUpTo(bound) {
+		if m >= 1 && sum == number {
+			result.Witness = &m
+			break
+		}
+	}
+
+	switch {
+	case result.Witness != nil:
+		result.Exact = true
+		result.Result = fmt.Sprintf("touchable (aliquot sum of %d)", *result.Witness)
+	case exact:
+		result.IsUntouchable, result.Exact = true, true
+		result.Result = "untouchable"
+	default:
+		result.IsUntouchable = true
+		result.Result = fmt.Sprintf("untouchable (verified up to %d)", bound)
+	}
+	render(c, http.StatusOK, result)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// vampireResult is the body of GET /api/vampire.
+type vampireResult struct {
+	Number    int      `json:"number"`
+	IsVampire bool     `json:"is_vampire"`
+	Fangs     [][2]int `json:"fangs"` // Every fang pair, smaller fang first
+}
+
+// vampire reports whether a number is a vampire number and lists its fangs.
+func vampire(c *gin.Context) {
+	number, ok := numberQuery(c)
+	if !ok {
+		return
+	}
+	switch {
+	case number < 0:
+		respondError(c, http.StatusBadRequest, c.Query("number"), "number must not be negative")
+		return
+	case len(strconv.Itoa(number)) > cfg.VampireMaxDigits:
+		respondError(c, http.StatusBadRequest, c.Query("number"), fmt.Sprintf("number must have at most %d digits", cfg.VampireMaxDigits))
+		return
+	}
+	fangs := vampireFangs(number)
+	render(c, http.StatusOK, vampireResult{Number: number, IsVampire: len(fangs) > 0, Fangs: fangs})
+}
+
+// vampireFangs returns the pairs x <= y with x × y = n where n has 2k digits,
+// x and y have k digits each, together they use exactly n's digits, and they
+// don't both end in 0 (1260 = 21 × 60, but not 126000 = 210 × 600). Pairs
+// come in canonical order, by ascending x. Only x from about n/10^k up to √n
+// can have a k-digit partner.
+func vampireFangs(n int) [][2]int {
+	fangs := [][2]int{}
+	digits := len(strconv.Itoa(n))
+	if n <= 0 || digits%2 != 0 {
+		return fangs
+	}
+	low, high := 1, 1
+	for i := 0; i < digits/2; i++ {
+		low, high = high, high*10 // 10^(k-1) and 10^k
+	}
+	want := digitCounts(uint64(n), 10)
+	for x := max(low, n/high); x <= isqrt(n); x++ {
+		if n%x != 0 {
+			continue
+		}
+		y := n / x
+		if y >= high || (x%10 == 0 && y%10 == 0) {
+			continue
+		}
+		got := digitCounts(uint64(x)*uint64(high)+uint64(y), 10) // x's digits then y's
+		if slices.Equal(got, want) {
+			fangs = append(fangs, [2]int{x, y})
+		}
+	}
+	return fangs
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestVampireFangs(t *testing.T) {
+	tests := []struct {
+		n    int
+		want [][2]int
+	}{
+		{1260, [][2]int{{21, 60}}},
+		{125460, [][2]int{{204, 615}, {246, 510}}},
+		{1261, [][2]int{}},
+	}
+	for _, tt := range tests {
+		w := get(t, "/api/vampire?number="+strconv.Itoa(tt.n))
+		var body vampireResult
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("%d: %d %s", tt.n, w.Code, w.Body)
+		}
+		if !slices.Equal(body.Fangs, tt.want) || body.IsVampire != (len(tt.want) > 0) {
+			t.Errorf("%d: fangs %v, is_vampire %v, want %v", tt.n, body.Fangs, body.IsVampire, tt.want)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adidazbot/num_class_api/numclass"
+	"github.com/gin-gonic/gin"
+)
+
+// verboseDetails and representations are defined in the numclass package.
+type (
+	verboseDetails  = numclass.VerboseDetails
+	representations = numclass.Representations
+)
+
+// Factorization formats accepted by ?factor_format=.
+const (
+	factorFormatPlain = "plain" // 2^3 * 3^2 * 5
+	factorFormatLatex = "latex" // 2^{3} \cdot 3^{2} \cdot 5
+)
+
+// factorFormatters write a factorization in each ?factor_format= format.
+// The empty product, 1's, is written 1.
+var factorFormatters = map[string]func([]primeFactor) string{
+	factorFormatPlain: func(factors []primeFactor) string { return joinFactors(factors, "%d^%d", " * ") },
+	factorFormatLatex: func(factors []primeFactor) string { return joinFactors(factors, "%d^{%d}", " \\cdot ") },
+}
+
+// joinFactors writes each prime, with its exponent in power's layout when
+// above 1, separated by sep.
+func joinFactors(factors []primeFactor, power, sep string) string {
+	if len(factors) == 0 {
+		return "1"
+	}
+	parts := make([]string, len(factors))
+	for i, f := range factors {
+		parts[i] = strconv.Itoa(f.Prime)
+		if f.Exponent > 1 {
+			parts[i] = fmt.Sprintf(power, f.Prime, f.Exponent)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// factorFormatQuery reads ?factor_format=, which is empty when absent.
+func factorFormatQuery(c *gin.Context) (string, bool) {
+	format, present := c.GetQuery("factor_format")
+	if !present {
+		return "", true
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if _, ok := factorFormatters[format]; !ok {
+		respondError(c, http.StatusBadRequest, c.Query("factor_format"), "factor_format must be plain or latex")
+		return "", false
+	}
+	recordInput(c, "factor_format", format)
+	return format, true
+}
+
+// describe gathers the verbose details for a number, writing the
+// factorization out too when factorFormat names a format. Factoring makes it
+// the most expensive part of a classification for large inputs.
+func describe(number int, factorFormat string) *verboseDetails {
+	details := &verboseDetails{
+		AllProperties: []string{},
+		Representations: representations{
+			Binary:      strconv.FormatInt(int64(number), 2),
+			Octal:       strconv.FormatInt(int64(number), 8),
+			Decimal:     strconv.FormatInt(int64(number), 10),
+			Hexadecimal: strconv.FormatInt(int64(number), 16),
+		},
+		Words: numberToWords(number),
+	}
+	for _, name := range propertyNames() {
+		if propertyRegistry[name](number) {
+			details.AllProperties = append(details.AllProperties, name)
+		}
+	}
+	if roman, ok := toRoman(number); ok {
+		details.Roman = &roman
+	}
+	if number >= 1 {
+		factors := factorize(number)
+		phi, count := totient(number, factors), divisorCount(factors)
+		details.Totient, details.DivisorCount = &phi, &count
+		details.Divisors = divisorsFrom(factors, cfg.MaxDivisors)
+		details.DivisorsTruncated = len(details.Divisors) < count
+		details.Factorization = factors
+		if format, ok := factorFormatters[factorFormat]; ok {
+			text := format(factors) // The whole factorization, even when the list is cut short
+			details.FactorizationText = &text
+		}
+		if cfg.MaxDivisors > 0 && len(factors) > cfg.MaxDivisors {
+			details.Factorization, details.FactorizationTruncated = factors[:cfg.MaxDivisors], true
+		}
+	}
+	return details
+}
+
+// divisorsFrom lists the positive divisors, ascending, from a factorization,
+// stopping at the smallest limit of them when limit > 0. The list is cut
+// back to limit after each prime, so a number with 100,000 divisors never
+// builds them all: a divisor among the smallest limit overall is always a
+// multiple of one among the smallest limit so far.
+func divisorsFrom(factors []primeFactor, limit int) []int {
+	divisors := []int{1}
+	for _, f := range factors {
+		current := len(divisors)
+		power := 1
+		for e := 0; e < f.Exponent; e++ {
+			power *= f.Prime
+			for _, d := range divisors[:current] {
+				divisors = append(divisors, d*power)
+			}
+		}
+		sort.Ints(divisors)
+		if limit > 0 && len(divisors) > limit {
+			divisors = divisors[:limit]
+		}
+	}
+	return divisors
+}
+
+// divisorCount is τ(n), the product of exponent + 1 over the factorization.
+func divisorCount(factors []primeFactor) int {
+	count := 1
+	for _, f := range factors {
+		count *= f.Exponent + 1
+	}
+	return count
+}
+
+// totient is Euler's φ(n) from the factorization of n >= 1.
+func totient(n int, factors []primeFactor) int {
+	phi := n
+	for _, f := range factors {
+		phi = phi / f.Prime * (f.Prime - 1)
+	}
+	return phi
+}
+
+// romanNumerals pairs values with their numerals, largest first.
+var romanNumerals = []struct {
+	value   int
+	numeral string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// toRoman writes 1-3999 as a Roman numeral.
+func toRoman(n int) (string, bool) {
+	if n < 1 || n > 3999 {
+		return "", false
+	}
+	var b strings.Builder
+	for _, r := range romanNumerals {
+		for ; n >= r.value; n -= r.value {
+			b.WriteString(r.numeral)
+		}
+	}
+	return b.String(), true
+}
+
+var (
+	smallNumberWords = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+		"seventeen", "eighteen", "nineteen",
+	}
+	tensWords  = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	scaleWords = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// numberToWords spells a number in English, e.g. "minus forty-two".
+func numberToWords(n int) string {
+	if n == 0 {
+		return "zero"
+	}
+	m := magnitude(n)
+	var groups []string
+	for scale := 0; m > 0; scale++ {
+		if chunk := int(m % 1000); chunk != 0 {
+			words := hundredsToWords(chunk)
+			if scaleWords[scale] != "" {
+				words += " " + scaleWords[scale]
+			}
+			groups = append([]string{words}, groups...)
+		}
+		m /= 1000
+	}
+	words := strings.Join(groups, " ")
+	if n < 0 {
+		words = "minus " + words
+	}
+	return words
+}
+
+// hundredsToWords spells 1-999.
+func hundredsToWords(n int) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, smallNumberWords[n/100]+" hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		parts = append(parts, tensWords[n/10]+"-"+smallNumberWords[n%10])
+	case n >= 20:
+		parts = append(parts, tensWords[n/10])
+	case n > 0:
+		parts = append(parts, smallNumberWords[n])
+	}
+	return strings.Join(parts, " ")
+}
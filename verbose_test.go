@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestFactorFormatters(t *testing.T) {
+	tests := []struct {
+		n            int
+		plain, latex string
+	}{
+		{360, "2^3 * 3^2 * 5", `2^{3} \cdot 3^{2} \cdot 5`},
+		{97, "97", "97"},
+		{1, "1", "1"},
+	}
+	for _, tt := range tests {
+		factors := factorize(tt.n)
+		if got := factorFormatters[factorFormatPlain](factors); got != tt.plain {
+			t.Errorf("plain %d = %q, want %q", tt.n, got, tt.plain)
+		}
+		if got := factorFormatters[factorFormatLatex](factors); got != tt.latex {
+			t.Errorf("latex %d = %q, want %q", tt.n, got, tt.latex)
+		}
+	}
+}
+
+func TestFactorFormatQuery(t *testing.T) {
+	tests := []struct {
+		query  string
+		status int
+		text   string // Empty when factorization_text is omitted
+	}{
+		{"number=360&verbose=true&factor_format=latex", http.StatusOK, `2^{3} \cdot 3^{2} \cdot 5`},
+		{"number=360&verbose=true&factor_format=PLAIN", http.StatusOK, "2^3 * 3^2 * 5"},
+		{"number=97&verbose=true&factor_format=plain", http.StatusOK, "97"},
+		{"number=360&verbose=true", http.StatusOK, ""},
+		{"number=360&verbose=true&factor_format=mathml", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		w := get(t, "/api/classify-number?"+tt.query)
+		var body struct {
+			Verbose struct {
+				FactorizationText *string `json:"factorization_text"`
+			}
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || w.Code != tt.status {
+			t.Fatalf("%s: %d %s", tt.query, w.Code, w.Body)
+		}
+		got := ""
+		if body.Verbose.FactorizationText != nil {
+			got = *body.Verbose.FactorizationText
+		}
+		if got != tt.text {
+			t.Errorf("%s: factorization_text %q, want %q", tt.query, got, tt.text)
+		}
+	}
+}
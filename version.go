@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// latestAPIVersion is the response shape served to unversioned requests.
+// Shape-breaking changes go into a new version; v1 clients keep v1.
+const latestAPIVersion = 1
+
+// supportedAPIVersions lists every version still served.
+var supportedAPIVersions = []int{1}
+
+// apiVersionKey is the context key holding the negotiated version.
+const apiVersionKey = "api_version"
+
+// vendorMediaType matches Accept: application/vnd.numclass.v1+json.
+var vendorMediaType = regexp.MustCompile(`^application/vnd\.numclass\.v(\d+)\+json$`)
+
+// apiVersion picks the response version for a route group. A group pinned
+// by its path (/api/v1) always uses that version; the unversioned group
+// (pinned = 0) honours a vendor Accept header and defaults to the latest.
+// The choice is echoed in the API-Version header.
+func apiVersion(pinned int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version := pinned
+		if version == 0 {
+			version = latestAPIVersion
+			if requested, ok := acceptedAPIVersion(c.GetHeader("Accept")); ok {
+				if !isSupportedAPIVersion(requested) {
+					render(c, http.StatusNotAcceptable, gin.H{
+						"version":            requested,
+						"error":              true,
+						"message":            "unsupported API version",
+						"supported_versions": supportedAPIVersions,
+					})
+					c.Abort()
+					return
+				}
+				version = requested
+			}
+		}
+		c.Set(apiVersionKey, version)
+		c.Header("API-Version", strconv.Itoa(version))
+		c.Next()
+	}
+}
+
+// acceptedAPIVersion returns the version named by a vendor media type in
+// the Accept header, if any.
+func acceptedAPIVersion(accept string) (int, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if m := vendorMediaType.FindStringSubmatch(strings.ToLower(mediaType)); m != nil {
+			version, err := strconv.Atoi(m[1])
+			return version, err == nil
+		}
+	}
+	return 0, false
+}
+
+// isSupportedAPIVersion reports whether version is still served.
+func isSupportedAPIVersion(version int) bool {
+	for _, v := range supportedAPIVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
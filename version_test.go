@@ -0,0 +1,24 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnsupportedAPIVersionRendered(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/classify-number?number=28&format=xml", nil)
+	req.Header.Set("Accept", "application/vnd.numclass.v99+json")
+	testRouter.ServeHTTP(w, req)
+	if w.Code != http.StatusNotAcceptable {
+		t.Fatalf("status %d, want 406", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/xml") {
+		t.Errorf("Content-Type %q, want the requested XML", ct)
+	}
+	if !strings.Contains(w.Body.String(), "unsupported API version") {
+		t.Errorf("body %q", w.Body)
+	}
+}
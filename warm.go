@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// factCache holds pre-fetched Numbers API facts keyed by path. Only the
+// numbers in FUN_FACT_WARM_NUMBERS are stored, so it never grows with traffic.
+type factCache struct {
+	mu    sync.RWMutex
+	facts map[string]cachedFact
+}
+
+// cachedFact is a fact and when it stops being served.
+type cachedFact struct {
+	text    string
+	expires time.Time
+}
+
+// funFactCache is filled by the warmer and read by fetchFact.
+var funFactCache = &factCache{facts: map[string]cachedFact{}}
+
+// get returns the fact stored for path, if it hasn't expired.
+func (fc *factCache) get(path string) (string, bool) {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+	fact, ok := fc.facts[path]
+	if !ok || time.Now().After(fact.expires) {
+		return "", false
+	}
+	return fact.text, true
+}
+
+// put stores a fact for ttl.
+func (fc *factCache) put(path, text string, ttl time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.facts[path] = cachedFact{text: text, expires: time.Now().Add(ttl)}
+}
+
+// startFunFactWarmer fetches the fun facts of numbers in the background, and
+// fetches them again every three quarters of FUN_FACT_WARM_TTL so they are
+// refreshed before they expire. A fact that fails to refresh keeps being
+// served until its TTL runs out. It does nothing in static mode, which makes
+// no network calls.
+func startFunFactWarmer(numbers []int) {
+	if len(numbers) == 0 || cfg.FunFactMode == funFactStatic || cfg.FunFactWarmTTL <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(cfg.FunFactWarmTTL * 3 / 4)
+		defer ticker.Stop()
+		for {
+			warmFunFacts(numbers)
+			<-ticker.C
+		}
+	}()
+}
+
+// warmFunFacts fetches and caches each fact in turn. Requests take an
+// outbound slot like any other, so warming never exceeds
+// FUN_FACT_MAX_IN_FLIGHT and shares it with live traffic.
+func warmFunFacts(numbers []int) {
+	start := time.Now()
+	warmed := 0
+	for _, n := range numbers {
+		fact, err := requestFact(context.Background(), funFactPath(n, factTypes[0]))
+		if err != nil {
+			log.Printf("Failed to warm fun fact for %d: %v", n, err)
+			continue
+		}
+		funFactCache.put(funFactPath(n, factTypes[0]), fact, cfg.FunFactWarmTTL)
+		warmed++
+	}
+	log.Printf("Warmed %d of %d fun facts in %s", warmed, len(numbers), time.Since(start).Round(time.Millisecond))
+}
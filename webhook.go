@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/adidazbot/num_class_api/numclass"
+)
+
+// Callback delivery states.
+const (
+	callbackPending      = "pending"  // Job still running
+	callbackRetrying     = "retrying" // At least one attempt failed, more to come
+	callbackDelivered    = "delivered"
+	callbackDeadLettered = "dead_lettered" // Every attempt failed
+)
+
+// callbackAttempt is one delivery attempt of a job callback.
+type callbackAttempt struct {
+	Attempt int       `json:"attempt"`
+	At      time.Time `json:"at"`
+	Status  int       `json:"status,omitempty"` // HTTP status, if a response arrived
+	Error   string    `json:"error,omitempty"`
+}
+
+// callbackStatus is the delivery record served by GET /api/jobs/:id/callback-status.
+type callbackStatus struct {
+	JobID         string            `json:"job_id"`
+	CallbackURL   string            `json:"callback_url"`
+	State         string            `json:"state"`
+	MaxAttempts   int               `json:"max_attempts"`
+	Attempts      []callbackAttempt `json:"attempts"`
+	NextAttemptAt *time.Time        `json:"next_attempt_at,omitempty"`
+}
+
+// webhookSlots bounds the callback requests in flight across all jobs, so a
+// burst of finishing jobs can't flood receivers or exhaust sockets.
+var webhookSlots = make(chan struct{}, max(cfg.WebhookMaxInFlight, 1))
+
+// webhookClient delivers job callbacks. Its dialer refuses internal addresses
+// at connect time as well, so DNS rebinding can't slip past validation.
+var webhookClient = &http.Client{
+	Timeout: cfg.WebhookTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip != nil && isInternalIP(ip) && !cfg.webhookHostAllowed(host) {
+					return fmt.Errorf("callback to internal address %s refused", host)
+				}
+				return nil
+			},
+		}).DialContext,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse // Never follow redirects to unvalidated targets
+	},
+}
+
+// validateCallbackURL checks a client-supplied callback URL, rejecting
+// non-HTTP schemes and hosts resolving to internal addresses unless the host
+// is on the configured allowlist.
+func validateCallbackURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("callback_url must be an absolute http(s) URL")
+	}
+	host := u.Hostname()
+	if cfg.webhookHostAllowed(host) {
+		return nil
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil || len(ips) == 0 {
+		return errors.New("callback_url host does not resolve")
+	}
+	for _, ip := range ips {
+		if isInternalIP(ip) {
+			return errors.New("callback_url must not point to an internal address")
+		}
+	}
+	return nil
+}
+
+// isInternalIP reports whether ip is loopback, private, link-local or unspecified.
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsInterfaceLocalMulticast()
+}
+
+// webhookHostAllowed reports whether host is on WEBHOOK_ALLOWED_HOSTS.
+func (c Config) webhookHostAllowed(host string) bool {
+	for _, allowed := range c.WebhookAllowedHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliverCallback POSTs the finished job to its callback URL, retrying on
+// network errors and non-2xx responses. Each attempt is recorded in the store,
+// and a callback whose attempts all fail is dead-lettered.
+func (s *jobStore) deliverCallback(j numclass.Job, callbackURL string) {
+	j.Dedup = nil // Debug-only on GET, so not part of the callback body either
+	body, err := json.Marshal(j)
+	if err != nil {
+		log.Printf("Job %s: failed to encode callback: %v", j.ID, err)
+		s.finishCallback(j.ID, callbackDeadLettered)
+		webhookDeadLettered.Inc()
+		return
+	}
+
+	attempts := max(cfg.WebhookMaxAttempts, 1)
+	for attempt := 1; attempt <= attempts; attempt++ {
+		started := time.Now()
+		status, err := postCallback(callbackURL, j.ID, body)
+		record := callbackAttempt{Attempt: attempt, At: started, Status: status}
+		if err == nil {
+			s.recordCallbackAttempt(j.ID, record, nil)
+			s.finishCallback(j.ID, callbackDelivered)
+			log.Printf("Job %s: callback delivered on attempt %d", j.ID, attempt)
+			return
+		}
+		record.Error = sanitizeEcho(err.Error()) // Error may quote the client's URL
+		log.Printf("Job %s: callback attempt %d failed: %s", j.ID, attempt, record.Error)
+		if attempt == attempts {
+			s.recordCallbackAttempt(j.ID, record, nil)
+			break
+		}
+		delay := retryDelay(attempt)
+		next := time.Now().Add(delay)
+		s.recordCallbackAttempt(j.ID, record, &next)
+		time.Sleep(delay)
+	}
+	s.finishCallback(j.ID, callbackDeadLettered)
+	webhookDeadLettered.Inc()
+	log.Printf("Job %s: callback dead-lettered after %d attempts", j.ID, attempts)
+}
+
+// retryDelay returns the wait after the given failed attempt: a uniformly
+// random duration up to an exponentially growing ceiling ("full jitter"), so
+// callbacks that failed together don't all retry together.
+func retryDelay(attempt int) time.Duration {
+	ceiling := cfg.WebhookRetryMax
+	if base := cfg.WebhookRetryBase; base > 0 && attempt < 32 && base<<(attempt-1) < ceiling {
+		ceiling = base << (attempt - 1)
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// postCallback sends one signed delivery attempt, returning the response
+// status if one arrived. It waits for a free outbound slot first.
+func postCallback(callbackURL, jobID string, body []byte) (int, error) {
+	webhookSlots <- struct{}{}
+	defer func() { <-webhookSlots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.WebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Job-ID", jobID)
+	if cfg.WebhookSecret != "" {
+		req.Header.Set("X-Signature-256", "sha256="+signPayload(body, cfg.WebhookSecret))
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Drain a bounded amount so the connection can be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// signPayload returns the hex HMAC-SHA256 of body under secret, so receivers
+// can verify a callback came from this server.
+func signPayload(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
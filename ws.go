@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket timings: a client must answer a ping within wsPongWait, and a
+// single write may take at most wsWriteWait.
+const (
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+	wsWriteWait  = 10 * time.Second
+	wsQueueSize  = 16 // Numbers read ahead of the one being classified
+)
+
+// wsUpgrader accepts WebSocket handshakes from any origin, like the CORS policy.
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(*http.Request) bool { return true },
+}
+
+// wsError is sent back for a message that isn't a valid number.
+type wsError struct {
+	Number  string `json:"number"`
+	Error   bool   `json:"error"`
+	Message string `json:"message"`
+}
+
+// classifyWebSocket classifies each number the client sends, one per text
+// message, replying with one JSON message per number in order. Reading stops
+// while wsQueueSize numbers are waiting, so a fast client is slowed to the
+// classification rate instead of growing an unbounded queue, and closing the
+// socket cancels any fun-fact fetch still in flight.
+func classifyWebSocket(c *gin.Context) {
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return // Upgrade already wrote the HTTP error
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	pending := make(chan string, wsQueueSize)
+	go func() {
+		defer cancel() // A closed or broken socket stops the writer too
+		defer close(pending)
+		wsReadLoop(ctx, conn, pending)
+	}()
+
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		case raw, ok := <-pending:
+			if !ok {
+				return
+			}
+			if err := wsWriteJSON(conn, wsClassify(ctx, raw)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// wsReadLoop queues every text message until the socket closes, the client
+// stops answering pings, or ctx is cancelled.
+func wsReadLoop(ctx context.Context, conn *websocket.Conn, pending chan<- string) {
+	conn.SetReadLimit(int64(cfg.MaxNumberLength) + 1) // One byte over still gets the "too long" reply
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	for {
+		kind, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Printf("WebSocket closed unexpectedly: %v", err)
+			}
+			return
+		}
+		if kind != websocket.TextMessage {
+			continue
+		}
+		conn.SetReadDeadline(time.Now().Add(wsPongWait)) // Any message shows the client is alive
+		select {
+		case pending <- string(message):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// wsClassify classifies one message, or describes why it isn't a number.
+func wsClassify(ctx context.Context, raw string) any {
+	number, err := parseNumber(raw)
+	if err != nil {
+		if errors.Is(err, errTooLong) {
+			raw = truncateEcho(raw, cfg.MaxNumberLength)
+		}
+		return wsError{Number: sanitizeEcho(raw), Error: true, Message: err.Error()}
+	}
+	opts := classifyOptions{Context: ctx}
+	mask := enabledFields(reflect.TypeOf(Classification{}))
+	if overloaded() {
+		mask = liteMask(mask) // Only cheap fields while /api is overloaded
+		opts.Fields = mask
+	}
+	result := classify(number, opts)
+	if opts.Fields == nil {
+		stats.record(result) // Partial results would skew the property percentages
+	}
+	masked, err := maskValue(result, mask)
+	if err != nil {
+		return wsError{Number: sanitizeEcho(raw), Error: true, Message: "failed to format response"}
+	}
+	return masked
+}
+
+// wsWriteJSON sends v as one text message within wsWriteWait.
+func wsWriteJSON(conn *websocket.Conn, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return conn.WriteMessage(websocket.TextMessage, body)
+}